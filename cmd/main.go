@@ -2,26 +2,135 @@ package main
 
 import (
 	"butik/internal/delivery/http"
+	"butik/internal/delivery/http/middlewares"
+	"butik/internal/delivery/scheduler"
 	"butik/internal/infrastructure"
+	"butik/internal/repository"
+	"butik/internal/usecase"
 	"butik/pkg/utils"
+	"context"
+	"time"
 
-	"github.com/go-playground/validator/v10"
 	Echo "github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"gorm.io/gorm"
 )
 
 func main() {
 	infrastructure.LoadEnv()
 	db := infrastructure.SetupDB()
 	e := Echo.New()
-	e.Validator = &utils.CustomValidator{Validator: validator.New()}
+	e.Validator = utils.NewCustomValidator(infrastructure.LoadStoreProfile().Language)
 
 	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
 		AllowOrigins:     []string{"http://localhost:5173"},
 		AllowMethods:     []string{Echo.GET, Echo.PUT, Echo.POST, Echo.DELETE},
 		AllowCredentials: true,
 	}))
-	http.RegisterRoutes(e, db)
+
+	app := newApp(db)
+	http.RegisterRoutes(e, app.http, app.idempotency)
+
+	jobs := scheduler.NewScheduler(repository.NewJobRepo(db))
+	scheduler.RegisterJobs(jobs, app.jobs)
+	jobs.Start(context.Background())
 
 	e.Logger.Fatal(e.Start(":" + infrastructure.GetEnv("PORT")))
 }
+
+// app hasil perakitan repo dan usecase untuk route HTTP dan job berkala
+type app struct {
+	http        http.Usecases
+	jobs        scheduler.Usecases
+	idempotency Echo.MiddlewareFunc
+}
+
+// newApp rakit semua repo, adapter dan usecase dari konfigurasi env
+func newApp(db *gorm.DB) app {
+	// User
+	userRepo := repository.NewUserRepo(db)
+	userUsecase := usecase.NewUserUsecase(userRepo)
+
+	// Category
+	categoryRepo := repository.NewCategoryRepo(db)
+	categoryUsecase := usecase.NewCategoryUsecase(categoryRepo)
+
+	// Product
+	productRepo := repository.NewProductRepo(db)
+	stockMovementRepo := repository.NewStockMovementRepo(db)
+	lowStockThreshold := infrastructure.GetEnvInt("LOW_STOCK_THRESHOLD", 5)
+	productUsecase := usecase.NewProductUsecase(productRepo, categoryRepo, stockMovementRepo, lowStockThreshold)
+
+	// Voucher
+	voucherRepo := repository.NewVoucherRepo(db)
+	voucherUsecase := usecase.NewVoucherUsecase(voucherRepo, categoryRepo, productRepo)
+
+	// Promotion
+	promotionRepo := repository.NewPromotionRepo(db)
+	promotionUsecase := usecase.NewPromotionUsecase(promotionRepo, categoryRepo, productRepo)
+
+	// Delivery
+	deliveryRateRepo := repository.NewDeliveryRateRepo(db)
+	deliveryRateUsecase := usecase.NewDeliveryRateUsecase(deliveryRateRepo)
+
+	deliveryConfig := infrastructure.LoadDeliveryConfig()
+	deliveryZoneRepo := repository.NewDeliveryZoneRepo(db)
+	deliveryZoneUsecase := usecase.NewDeliveryZoneUsecase(deliveryZoneRepo, deliveryConfig.Location)
+
+	// Order
+	orderRepo := repository.NewOrderRepo(db)
+	storeProfile := infrastructure.LoadStoreProfile()
+	messageTemplateRepo := repository.NewMessageTemplateRepo(db)
+	messageTemplateUsecase := usecase.NewMessageTemplateUsecase(messageTemplateRepo, orderRepo, storeProfile)
+	notificationRepo := repository.NewNotificationRepo(db)
+	notificationUsecase := usecase.NewNotificationUsecase(notificationRepo, orderRepo, messageTemplateRepo, infrastructure.NewNotificationProviders(), storeProfile, infrastructure.GetEnvInt("NOTIFICATION_MAX_ATTEMPTS", 5))
+	webhookRepo := repository.NewWebhookRepo(db)
+	webhookUsecase := usecase.NewWebhookUsecase(webhookRepo, infrastructure.NewHTTPWebhookSender(time.Duration(infrastructure.GetEnvInt("WEBHOOK_TIMEOUT_SECONDS", 10))*time.Second), infrastructure.GetEnvInt("WEBHOOK_MAX_ATTEMPTS", 8))
+	alerts := infrastructure.NewAlertNotifier()
+	paymentRepo := repository.NewPaymentRepo(db)
+	paymentExpiry := time.Duration(infrastructure.GetEnvInt("PAYMENT_EXPIRY_MINUTES", 60)) * time.Minute
+	paymentUsecase := usecase.NewPaymentUsecase(paymentRepo, orderRepo, infrastructure.NewPaymentProvider(), paymentExpiry, notificationUsecase, webhookUsecase, alerts)
+	stockReservationRepo := repository.NewStockReservationRepo(db)
+	autoCancelAge, reservationTTL := infrastructure.LoadOrderTimeouts()
+	orderUsecase := usecase.NewOrderUsecase(orderRepo, productRepo, voucherRepo, promotionRepo, deliveryRateRepo, deliveryZoneRepo, deliveryConfig, infrastructure.NewCourierProvider(deliveryConfig.OriginCity), stockReservationRepo, reservationTTL, alerts, lowStockThreshold, infrastructure.NewPDFInvoiceRenderer(storeProfile, deliveryConfig.Location), infrastructure.LoadOrderNumberFormat(deliveryConfig.Location), notificationUsecase, webhookUsecase, paymentUsecase)
+	idempotencyRepo := repository.NewIdempotencyRepo(db)
+	idempotencyTTL := time.Duration(infrastructure.GetEnvInt("IDEMPOTENCY_TTL_HOURS", 24)) * time.Hour
+
+	// Customer
+	customerRepo := repository.NewCustomerRepo(db)
+	customerUsecase := usecase.NewCustomerUsecase(customerRepo, orderRepo, notificationUsecase)
+
+	// Report
+	reportRepo := repository.NewReportRepo(db)
+	reportUsecase := usecase.NewReportUsecase(reportRepo, deliveryConfig.Location)
+
+	return app{
+		http: http.Usecases{
+			User:            userUsecase,
+			Category:        categoryUsecase,
+			Product:         productUsecase,
+			Voucher:         voucherUsecase,
+			Promotion:       promotionUsecase,
+			DeliveryRate:    deliveryRateUsecase,
+			DeliveryZone:    deliveryZoneUsecase,
+			MessageTemplate: messageTemplateUsecase,
+			Notification:    notificationUsecase,
+			Webhook:         webhookUsecase,
+			Payment:         paymentUsecase,
+			Order:           orderUsecase,
+			Customer:        customerUsecase,
+			Report:          reportUsecase,
+		},
+		jobs: scheduler.Usecases{
+			Order:           orderUsecase,
+			Product:         productUsecase,
+			Notification:    notificationUsecase,
+			Webhook:         webhookUsecase,
+			Payment:         paymentUsecase,
+			Customer:        customerUsecase,
+			IdempotencyKeys: idempotencyRepo,
+			AutoCancelAge:   autoCancelAge,
+		},
+		idempotency: middlewares.IdempotencyMiddleware(idempotencyRepo, idempotencyTTL),
+	}
+}
@@ -0,0 +1,96 @@
+package http
+
+import (
+	"butik/internal/delivery/http/middlewares"
+	"butik/internal/domain"
+	"butik/internal/usecase"
+	"butik/pkg/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type customerHandler struct {
+	Usecase usecase.CustomerUsecase
+}
+
+func RegisterCustomerRoutes(e *echo.Echo, customerUsecase usecase.CustomerUsecase) {
+	handler := &customerHandler{Usecase: customerUsecase}
+
+	// Protected
+	customerGroup := e.Group("/customers", middlewares.JWTMiddleware())
+	customerGroup.GET("", handler.GetAllCustomers)
+	customerGroup.GET("/:id", handler.GetCustomerDetail)
+}
+
+func (h *customerHandler) GetAllCustomers(c echo.Context) error {
+	page := 1
+	limit := 10
+
+	if p, err := strconv.Atoi(c.QueryParam("page")); err == nil && p > 0 {
+		page = p
+	}
+	if l, err := strconv.Atoi(c.QueryParam("limit")); err == nil && l > 0 {
+		limit = l
+	}
+
+	var query domain.CustomerListQuery
+	if err := c.Bind(&query); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid query parameters"})
+	}
+
+	if err := c.Validate(&query); err != nil {
+		return utils.ValidationErrorResponse(c, err)
+	}
+
+	offset := (page - 1) * limit
+	customers, total, err := h.Usecase.GetAllCustomers(query, offset, limit)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	response := map[string]interface{}{
+		"data":  customers,
+		"total": total,
+		"page":  page,
+		"limit": limit,
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+func (h *customerHandler) GetCustomerDetail(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid customer id"})
+	}
+
+	page := 1
+	limit := 10
+
+	if p, err := strconv.Atoi(c.QueryParam("page")); err == nil && p > 0 {
+		page = p
+	}
+	if l, err := strconv.Atoi(c.QueryParam("limit")); err == nil && l > 0 {
+		limit = l
+	}
+
+	offset := (page - 1) * limit
+	detail, total, err := h.Usecase.GetCustomerDetail(uint(id), offset, limit)
+	if err != nil {
+		if err.Error() == "customer not found" {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	response := map[string]interface{}{
+		"customer":  detail.Customer,
+		"addresses": detail.Addresses,
+		"data":      detail.Orders,
+		"total":     total,
+		"page":      page,
+		"limit":     limit,
+	}
+	return c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,93 @@
+package http
+
+import (
+	"butik/internal/delivery/http/middlewares"
+	"butik/internal/domain"
+	"butik/internal/usecase"
+	"butik/pkg/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type deliveryRateHandler struct {
+	Usecase usecase.DeliveryRateUsecase
+}
+
+func RegisterDeliveryRateRoutes(e *echo.Echo, deliveryRateUsecase usecase.DeliveryRateUsecase) {
+	handler := &deliveryRateHandler{Usecase: deliveryRateUsecase}
+
+	// Public
+	e.GET("/delivery-rates", handler.GetAllDeliveryRates)
+
+	// Protected
+	rateGroup := e.Group("/delivery-rates", middlewares.JWTMiddleware())
+	rateGroup.POST("", handler.CreateDeliveryRate)
+	rateGroup.PUT("/:id", handler.UpdateDeliveryRate)
+	rateGroup.DELETE("/:id", handler.DeleteDeliveryRate)
+}
+
+func (h *deliveryRateHandler) CreateDeliveryRate(c echo.Context) error {
+	var req domain.CreateDeliveryRateRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return utils.ValidationErrorResponse(c, err)
+	}
+
+	res, err := h.Usecase.CreateDeliveryRate(req)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, res)
+}
+
+func (h *deliveryRateHandler) GetAllDeliveryRates(c echo.Context) error {
+	rates, err := h.Usecase.GetAllDeliveryRates()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{"data": rates})
+}
+
+func (h *deliveryRateHandler) UpdateDeliveryRate(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid delivery rate id"})
+	}
+
+	var req domain.UpdateDeliveryRateRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return utils.ValidationErrorResponse(c, err)
+	}
+
+	res, err := h.Usecase.UpdateDeliveryRate(uint(id), req)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h *deliveryRateHandler) DeleteDeliveryRate(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid delivery rate id"})
+	}
+
+	res, err := h.Usecase.DeleteDeliveryRate(uint(id))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,128 @@
+package http
+
+import (
+	"butik/internal/delivery/http/middlewares"
+	"butik/internal/domain"
+	"butik/internal/usecase"
+	"butik/pkg/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type deliveryZoneHandler struct {
+	Usecase usecase.DeliveryZoneUsecase
+}
+
+func RegisterDeliveryZoneRoutes(e *echo.Echo, deliveryZoneUsecase usecase.DeliveryZoneUsecase) {
+	handler := &deliveryZoneHandler{Usecase: deliveryZoneUsecase}
+
+	// Public
+	e.GET("/delivery-zones/lookup", handler.LookupDeliveryZone)
+
+	// Protected
+	zoneGroup := e.Group("/delivery-zones", middlewares.JWTMiddleware())
+	zoneGroup.GET("", handler.GetAllDeliveryZones)
+	zoneGroup.GET("/:id", handler.GetDeliveryZoneByID)
+	zoneGroup.POST("", handler.CreateDeliveryZone)
+	zoneGroup.PUT("/:id", handler.UpdateDeliveryZone)
+	zoneGroup.DELETE("/:id", handler.DeleteDeliveryZone)
+}
+
+func (h *deliveryZoneHandler) CreateDeliveryZone(c echo.Context) error {
+	var req domain.CreateDeliveryZoneRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return utils.ValidationErrorResponse(c, err)
+	}
+
+	res, err := h.Usecase.CreateDeliveryZone(req)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, res)
+}
+
+func (h *deliveryZoneHandler) GetAllDeliveryZones(c echo.Context) error {
+	zones, err := h.Usecase.GetAllDeliveryZones()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{"data": zones})
+}
+
+func (h *deliveryZoneHandler) GetDeliveryZoneByID(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid delivery zone id"})
+	}
+
+	res, err := h.Usecase.GetDeliveryZoneByID(uint(id))
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h *deliveryZoneHandler) LookupDeliveryZone(c echo.Context) error {
+	latitude, err := strconv.ParseFloat(c.QueryParam("latitude"), 64)
+	if err != nil || latitude < -90 || latitude > 90 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid latitude"})
+	}
+
+	longitude, err := strconv.ParseFloat(c.QueryParam("longitude"), 64)
+	if err != nil || longitude < -180 || longitude > 180 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid longitude"})
+	}
+
+	res, err := h.Usecase.LookupDeliveryZone(latitude, longitude)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h *deliveryZoneHandler) UpdateDeliveryZone(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid delivery zone id"})
+	}
+
+	var req domain.UpdateDeliveryZoneRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return utils.ValidationErrorResponse(c, err)
+	}
+
+	res, err := h.Usecase.UpdateDeliveryZone(uint(id), req)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h *deliveryZoneHandler) DeleteDeliveryZone(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid delivery zone id"})
+	}
+
+	res, err := h.Usecase.DeleteDeliveryZone(uint(id))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
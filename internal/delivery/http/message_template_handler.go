@@ -0,0 +1,103 @@
+package http
+
+import (
+	"butik/internal/delivery/http/middlewares"
+	"butik/internal/domain"
+	"butik/internal/usecase"
+	"butik/pkg/utils"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+type messageTemplateHandler struct {
+	Usecase usecase.MessageTemplateUsecase
+}
+
+func RegisterMessageTemplateRoutes(e *echo.Echo, messageTemplateUsecase usecase.MessageTemplateUsecase) {
+	handler := &messageTemplateHandler{Usecase: messageTemplateUsecase}
+
+	// Protected
+	templateGroup := e.Group("/message-templates", middlewares.JWTMiddleware())
+	templateGroup.GET("", handler.GetAllTemplates)
+	templateGroup.POST("/preview", handler.PreviewTemplate)
+	templateGroup.GET("/:event/:language", handler.GetTemplate)
+	templateGroup.PUT("/:event/:language", handler.SaveTemplate)
+	templateGroup.DELETE("/:event/:language", handler.ResetTemplate)
+}
+
+func (h *messageTemplateHandler) GetAllTemplates(c echo.Context) error {
+	templates, err := h.Usecase.GetAllTemplates()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	response := map[string]interface{}{
+		"data":      templates,
+		"variables": h.Usecase.GetVariables(),
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+func (h *messageTemplateHandler) GetTemplate(c echo.Context) error {
+	template, err := h.Usecase.GetTemplate(domain.NotificationEvent(c.Param("event")), c.Param("language"))
+	if err != nil {
+		return templateErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, template)
+}
+
+func (h *messageTemplateHandler) SaveTemplate(c echo.Context) error {
+	var req domain.SaveMessageTemplateRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return utils.ValidationErrorResponse(c, err)
+	}
+
+	res, err := h.Usecase.SaveTemplate(domain.NotificationEvent(c.Param("event")), c.Param("language"), req, middlewares.CurrentUserID(c))
+	if err != nil {
+		return templateErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h *messageTemplateHandler) ResetTemplate(c echo.Context) error {
+	res, err := h.Usecase.ResetTemplate(domain.NotificationEvent(c.Param("event")), c.Param("language"))
+	if err != nil {
+		return templateErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h *messageTemplateHandler) PreviewTemplate(c echo.Context) error {
+	var req domain.PreviewMessageTemplateRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return utils.ValidationErrorResponse(c, err)
+	}
+
+	res, err := h.Usecase.PreviewTemplate(req)
+	if err != nil {
+		return templateErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, res)
+}
+
+func templateErrorResponse(c echo.Context, err error) error {
+	switch {
+	case err.Error() == "unknown notification event", err.Error() == "unsupported language", errors.Is(err, domain.ErrOrderNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	case strings.HasPrefix(err.Error(), "invalid template"):
+		return c.JSON(http.StatusUnprocessableEntity, map[string]string{"error": err.Error()})
+	default:
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+}
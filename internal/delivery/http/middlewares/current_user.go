@@ -0,0 +1,24 @@
+package middlewares
+
+import (
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/labstack/echo/v4"
+)
+
+// CurrentUserID user_id dari token JWT yang sudah diverifikasi JWTMiddleware, nil jika tidak ada
+func CurrentUserID(c echo.Context) *uint {
+	token, ok := c.Get("user").(*jwt.Token)
+	if !ok {
+		return nil
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil
+	}
+	userID, ok := claims["user_id"].(float64)
+	if !ok {
+		return nil
+	}
+	id := uint(userID)
+	return &id
+}
@@ -0,0 +1,190 @@
+package middlewares
+
+import (
+	"bufio"
+	"butik/internal/domain"
+	"butik/internal/repository"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	IdempotencyKeyHeader      = "Idempotency-Key"
+	idempotentReplayedHeader  = "Idempotent-Replayed"
+	maxIdempotencyKeyLength   = 255
+	defaultIdempotencyTTLHour = 24
+	// idempotencyLease lama key dikunci selama request diproses
+	idempotencyLease = 2 * time.Minute
+)
+
+// IdempotencyMiddleware menyimpan response pertama per Idempotency-Key dan
+// mengirim ulang response tersebut untuk retry dengan payload yang sama.
+// Request tanpa header diteruskan seperti biasa.
+func IdempotencyMiddleware(repo repository.IdempotencyRepo, ttl time.Duration) echo.MiddlewareFunc {
+	if ttl <= 0 {
+		ttl = defaultIdempotencyTTLHour * time.Hour
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			key := strings.TrimSpace(c.Request().Header.Get(IdempotencyKeyHeader))
+			if key == "" {
+				return next(c)
+			}
+			if len(key) > maxIdempotencyKeyLength {
+				return c.JSON(http.StatusBadRequest, map[string]string{"error": "idempotency key is too long"})
+			}
+
+			requestHash, err := hashRequest(c)
+			if err != nil {
+				return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request format"})
+			}
+
+			scope := c.Request().Method + " " + c.Path()
+			now := time.Now()
+			record, created, err := repo.Reserve(key, scope, requestHash, now.Add(ttl), now.Add(idempotencyLease))
+			if err != nil {
+				return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+			}
+
+			if !created {
+				if record.RequestHash != requestHash {
+					return c.JSON(http.StatusUnprocessableEntity, map[string]string{"error": "idempotency key already used with a different payload"})
+				}
+				if record.Status != domain.IdempotencyStatusCompleted {
+					return c.JSON(http.StatusConflict, map[string]string{"error": "a request with this idempotency key is still being processed"})
+				}
+				c.Response().Header().Set(idempotentReplayedHeader, "true")
+				return c.Blob(record.StatusCode, echo.MIMEApplicationJSONCharsetUTF8, []byte(record.ResponseBody))
+			}
+
+			recorder := &responseRecorder{ResponseWriter: c.Response().Writer}
+			c.Response().Writer = recorder
+
+			if err := next(c); err != nil {
+				c.Error(err)
+			}
+
+			// Server error tidak disimpan supaya client bisa retry dengan key yang sama,
+			// penolakan 4xx disimpan karena retry dengan payload sama akan ditolak lagi
+			if c.Response().Status >= http.StatusInternalServerError {
+				releaseIdempotencyKey(repo, key, scope)
+				return nil
+			}
+			// Order mungkin sudah dibuat, key dibiarkan processing sampai lease habis
+			// supaya retry tidak langsung membuat order kedua
+			if err := repo.Complete(key, scope, c.Response().Status, recorder.body.String()); err != nil {
+				log.Printf("Failed to store idempotent response for key %s: %v", key, err)
+			}
+			return nil
+		}
+	}
+}
+
+// releaseIdempotencyKey jika gagal, key terbuka lagi setelah lease habis
+func releaseIdempotencyKey(repo repository.IdempotencyRepo, key, scope string) {
+	if err := repo.Release(key, scope); err != nil {
+		log.Printf("Failed to release idempotency key %s: %v", key, err)
+	}
+}
+
+// hashRequest hash dari isi form/body, bukan raw body, karena boundary multipart berubah di setiap retry
+func hashRequest(c echo.Context) (string, error) {
+	hasher := sha256.New()
+	req := c.Request()
+
+	if strings.HasPrefix(req.Header.Get(echo.HeaderContentType), echo.MIMEMultipartForm) {
+		form, err := c.MultipartForm()
+		if err != nil {
+			return "", err
+		}
+
+		writeSortedValues(hasher, form.Value)
+
+		fieldNames := make([]string, 0, len(form.File))
+		for name := range form.File {
+			fieldNames = append(fieldNames, name)
+		}
+		sort.Strings(fieldNames)
+		for _, name := range fieldNames {
+			for _, fileHeader := range form.File[name] {
+				file, err := fileHeader.Open()
+				if err != nil {
+					return "", err
+				}
+				io.WriteString(hasher, name+"\x00"+fileHeader.Filename+"\x00")
+				_, err = io.Copy(hasher, file)
+				file.Close()
+				if err != nil {
+					return "", err
+				}
+			}
+		}
+		return hex.EncodeToString(hasher.Sum(nil)), nil
+	}
+
+	if strings.HasPrefix(req.Header.Get(echo.HeaderContentType), echo.MIMEApplicationForm) {
+		form, err := c.FormParams()
+		if err != nil {
+			return "", err
+		}
+		writeSortedValues(hasher, form)
+		return hex.EncodeToString(hasher.Sum(nil)), nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return "", err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	hasher.Write(body)
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+func writeSortedValues(w io.Writer, values map[string][]string) {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, value := range values[name] {
+			io.WriteString(w, name+"\x00"+value+"\x00")
+		}
+	}
+}
+
+// responseRecorder menyalin body response sambil tetap menulis ke client
+type responseRecorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *responseRecorder) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (w *responseRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if hijacker, ok := w.ResponseWriter.(http.Hijacker); ok {
+		return hijacker.Hijack()
+	}
+	return nil, nil, errors.New("response writer does not support hijacking")
+}
@@ -0,0 +1,92 @@
+package http
+
+import (
+	"butik/internal/delivery/http/middlewares"
+	"butik/internal/domain"
+	"butik/internal/usecase"
+	"butik/pkg/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type notificationHandler struct {
+	Usecase usecase.NotificationUsecase
+}
+
+func RegisterNotificationRoutes(e *echo.Echo, notificationUsecase usecase.NotificationUsecase) {
+	handler := &notificationHandler{Usecase: notificationUsecase}
+
+	// Protected
+	notificationGroup := e.Group("/notifications", middlewares.JWTMiddleware())
+	notificationGroup.GET("", handler.GetAllNotifications)
+	notificationGroup.GET("/:id", handler.GetNotificationByID)
+	notificationGroup.POST("/:id/retry", handler.RetryNotification)
+}
+
+func (h *notificationHandler) GetAllNotifications(c echo.Context) error {
+	page := 1
+	limit := 10
+
+	if p, err := strconv.Atoi(c.QueryParam("page")); err == nil && p > 0 {
+		page = p
+	}
+	if l, err := strconv.Atoi(c.QueryParam("limit")); err == nil && l > 0 {
+		limit = l
+	}
+
+	var query domain.NotificationListQuery
+	if err := c.Bind(&query); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid query parameters"})
+	}
+
+	if err := c.Validate(&query); err != nil {
+		return utils.ValidationErrorResponse(c, err)
+	}
+
+	offset := (page - 1) * limit
+	notifications, total, err := h.Usecase.GetAllNotifications(query, offset, limit)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	response := map[string]interface{}{
+		"data":  notifications,
+		"total": total,
+		"page":  page,
+		"limit": limit,
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+func (h *notificationHandler) GetNotificationByID(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid notification id"})
+	}
+
+	notification, err := h.Usecase.GetNotificationByID(uint(id))
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, notification)
+}
+
+func (h *notificationHandler) RetryNotification(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid notification id"})
+	}
+
+	res, err := h.Usecase.RetryNotification(uint(id))
+	if err != nil {
+		if err.Error() == "notification not found" {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusUnprocessableEntity, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
@@ -6,8 +6,11 @@ import (
 	"butik/internal/usecase"
 	"butik/pkg/utils"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/labstack/echo/v4"
 )
@@ -16,17 +19,24 @@ type orderHandler struct {
 	Usecase usecase.OrderUsecase
 }
 
-func RegisterOrderRoutes(e *echo.Echo, orderUsecase usecase.OrderUsecase) {
+func RegisterOrderRoutes(e *echo.Echo, orderUsecase usecase.OrderUsecase, idempotency echo.MiddlewareFunc) {
 	handler := &orderHandler{Usecase: orderUsecase}
 
 	// Public
-	e.POST("/orders", handler.CreateOrder)
+	e.POST("/orders", handler.CreateOrder, idempotency)
+	e.POST("/orders/quote", handler.QuoteOrder)
 	e.GET("/orders/:id", handler.GetOrderByID)
+	e.GET("/orders/:id/tracking", handler.TrackOrder)
+	e.GET("/orders/:id/pickup-qr", handler.GetPickupQRCode)
+	e.GET("/orders/:id/invoice", handler.GetInvoice)
 
 	// Protected
 	orderGroup := e.Group("/orders", middlewares.JWTMiddleware())
 	orderGroup.GET("", handler.GetAllOrders)
+	orderGroup.GET("/export", handler.ExportOrders)
 	orderGroup.PUT("/:id/status", handler.UpdateOrderStatus)
+	orderGroup.PUT("/:id/airway-bill", handler.AttachAirwayBill)
+	orderGroup.POST("/:id/collect", handler.CollectOrder)
 	orderGroup.DELETE("/:id", handler.DeleteOrder)
 }
 
@@ -48,20 +58,51 @@ func (h *orderHandler) CreateOrder(c echo.Context) error {
 		return utils.ValidationErrorResponse(c, err)
 	}
 
-	// Handle file upload
-	proofOfPayment, err := utils.HandleFileUpload(c, "proof_of_payment", "uploads/payments")
-	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "proof of payment is required"})
+	// Bukti transfer hanya untuk transfer manual, pembayaran gateway dikonfirmasi lewat callback
+	var proofOfPayment string
+	if req.PaymentMethod != domain.PaymentMethodGateway {
+		var err error
+		proofOfPayment, err = utils.HandleFileUpload(c, "proof_of_payment", "uploads/payments")
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "proof of payment is required"})
+		}
 	}
 
 	res, err := h.Usecase.CreateOrder(req, proofOfPayment)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return orderErrorResponse(c, err)
 	}
 
 	return c.JSON(http.StatusCreated, res)
 }
 
+func (h *orderHandler) QuoteOrder(c echo.Context) error {
+	var req domain.QuoteOrderRequest
+
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request format"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return utils.ValidationErrorResponse(c, err)
+	}
+
+	res, err := h.Usecase.QuoteOrder(req)
+	if err != nil {
+		return orderErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// orderErrorResponse 422 untuk order yang ditolak karena isinya, selain itu kegagalan server
+func orderErrorResponse(c echo.Context, err error) error {
+	if errors.Is(err, domain.ErrOrderRejected) {
+		return c.JSON(http.StatusUnprocessableEntity, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+}
+
 func (h *orderHandler) GetAllOrders(c echo.Context) error {
 	pageStr := c.QueryParam("page")
 	limitStr := c.QueryParam("limit")
@@ -77,10 +118,19 @@ func (h *orderHandler) GetAllOrders(c echo.Context) error {
 		limit = l
 	}
 
+	var query domain.OrderListQuery
+	if err := c.Bind(&query); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid query parameters"})
+	}
+
+	if err := c.Validate(&query); err != nil {
+		return utils.ValidationErrorResponse(c, err)
+	}
+
 	offset := (page - 1) * limit
-	orders, total, err := h.Usecase.GetAllOrders(offset, limit)
+	orders, total, err := h.Usecase.GetAllOrders(query, offset, limit)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
 	}
 
 	response := map[string]interface{}{
@@ -92,6 +142,39 @@ func (h *orderHandler) GetAllOrders(c echo.Context) error {
 	return c.JSON(http.StatusOK, response)
 }
 
+func (h *orderHandler) ExportOrders(c echo.Context) error {
+	var query domain.ExportOrdersQuery
+	if err := c.Bind(&query); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid query parameters"})
+	}
+
+	if err := c.Validate(&query); err != nil {
+		return utils.ValidationErrorResponse(c, err)
+	}
+
+	contentType, extension := "text/csv; charset=utf-8", "csv"
+	if query.Format == "xlsx" {
+		contentType, extension = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", "xlsx"
+	}
+	filename := fmt.Sprintf("orders-%s.%s", time.Now().Format("20060102-150405"), extension)
+
+	header := c.Response().Header()
+	header.Set(echo.HeaderContentType, contentType)
+	header.Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename))
+
+	if err := h.Usecase.ExportOrders(query, c.Response()); err != nil {
+		// Setelah data mulai terkirim status tidak bisa diubah lagi
+		if c.Response().Committed {
+			c.Logger().Error("order export failed: ", err)
+			return nil
+		}
+		header.Del(echo.HeaderContentType)
+		header.Del(echo.HeaderContentDisposition)
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	return nil
+}
+
 func (h *orderHandler) GetOrderByID(c echo.Context) error {
 	id := c.Param("id")
 
@@ -127,12 +210,101 @@ func (h *orderHandler) UpdateOrderStatus(c echo.Context) error {
 	return c.JSON(http.StatusOK, res)
 }
 
+func (h *orderHandler) AttachAirwayBill(c echo.Context) error {
+	id := c.Param("id")
+
+	var req domain.AttachAirwayBillRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return utils.ValidationErrorResponse(c, err)
+	}
+
+	res, err := h.Usecase.AttachAirwayBill(id, req)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h *orderHandler) TrackOrder(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "order id is required"})
+	}
+
+	res, err := h.Usecase.TrackOrder(id)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h *orderHandler) CollectOrder(c echo.Context) error {
+	id := c.Param("id")
+
+	var req domain.CollectOrderRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return utils.ValidationErrorResponse(c, err)
+	}
+
+	res, err := h.Usecase.CollectOrder(id, req)
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h *orderHandler) GetPickupQRCode(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "order id is required"})
+	}
+
+	png, err := h.Usecase.GetPickupQRCode(id)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	}
+
+	return c.Blob(http.StatusOK, "image/png", png)
+}
+
+func (h *orderHandler) GetInvoice(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "order id is required"})
+	}
+
+	pdf, err := h.Usecase.GetInvoicePDF(id)
+	if err != nil {
+		if errors.Is(err, domain.ErrOrderNotFound) {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="invoice-%s.pdf"`, id))
+	return c.Blob(http.StatusOK, "application/pdf", pdf)
+}
+
 func (h *orderHandler) DeleteOrder(c echo.Context) error {
 	id := c.Param("id")
 	if id == "" {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "order id is required"})
 	}
 	if err := h.Usecase.DeleteOrder(id); err != nil {
+		if errors.Is(err, domain.ErrOrderNotFound) {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
 	return c.JSON(http.StatusOK, map[string]string{"message": "order deleted successfully"})
@@ -0,0 +1,92 @@
+package http
+
+import (
+	"butik/internal/delivery/http/middlewares"
+	"butik/internal/domain"
+	"butik/internal/usecase"
+	"butik/pkg/utils"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// paymentCallbackLimit batas body callback dari payment gateway
+const paymentCallbackLimit = 1 << 20
+
+type paymentHandler struct {
+	Usecase usecase.PaymentUsecase
+}
+
+func RegisterPaymentRoutes(e *echo.Echo, paymentUsecase usecase.PaymentUsecase) {
+	handler := &paymentHandler{Usecase: paymentUsecase}
+
+	// Public
+	e.POST("/orders/:id/payment", handler.CreatePayment)
+	e.POST("/payments/callback", handler.HandleCallback)
+
+	// Protected
+	paymentGroup := e.Group("/payments", middlewares.JWTMiddleware())
+	paymentGroup.POST("/:reference/simulate", handler.SimulatePayment)
+}
+
+func (h *paymentHandler) CreatePayment(c echo.Context) error {
+	res, err := h.Usecase.CreatePayment(c.Param("id"))
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrOrderNotFound):
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		case errors.Is(err, domain.ErrPaymentFailed), errors.Is(err, domain.ErrPaymentGatewayUnavailable):
+			return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusUnprocessableEntity, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, res)
+}
+
+// HandleCallback notifikasi dari payment gateway, selain 2xx akan dikirim ulang oleh provider
+func (h *paymentHandler) HandleCallback(c echo.Context) error {
+	body, err := io.ReadAll(io.LimitReader(c.Request().Body, paymentCallbackLimit))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request"})
+	}
+
+	res, err := h.Usecase.HandleCallback(c.Request().Header, body)
+	if err != nil {
+		return paymentErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h *paymentHandler) SimulatePayment(c echo.Context) error {
+	var req domain.SimulatePaymentRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return utils.ValidationErrorResponse(c, err)
+	}
+
+	res, err := h.Usecase.SimulatePayment(c.Param("reference"), req)
+	if err != nil {
+		return paymentErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func paymentErrorResponse(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, domain.ErrInvalidPaymentSignature):
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	case errors.Is(err, domain.ErrPaymentNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	case errors.Is(err, domain.ErrPaymentRejected), errors.Is(err, domain.ErrPaymentGatewayUnavailable):
+		return c.JSON(http.StatusUnprocessableEntity, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+}
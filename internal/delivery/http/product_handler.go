@@ -25,8 +25,12 @@ func RegisterProductRoutes(e *echo.Echo, productUsecase usecase.ProductUsecase)
 	// Protected
 	productGroup := e.Group("/products", middlewares.JWTMiddleware())
 	productGroup.POST("", handler.CreateProduct)
+	productGroup.POST("/restock", handler.BulkRestock)
+	productGroup.GET("/low-stock", handler.GetLowStockProducts)
 	productGroup.PUT("/:id", handler.UpdateProduct)
 	productGroup.DELETE("/:id", handler.DeleteProduct)
+	productGroup.GET("/:id/stock-movements", handler.GetStockHistory)
+	productGroup.POST("/:id/stock-adjustments", handler.AdjustStock)
 }
 
 func (h *productHandler) CreateProduct(c echo.Context) error {
@@ -45,7 +49,7 @@ func (h *productHandler) CreateProduct(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "image is required"})
 	}
 
-	res, err := h.Usecase.CreateProduct(req, imageURL)
+	res, err := h.Usecase.CreateProduct(req, imageURL, middlewares.CurrentUserID(c))
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
@@ -82,6 +86,32 @@ func (h *productHandler) GetAllProducts(c echo.Context) error {
 	return c.JSON(http.StatusOK, response)
 }
 
+func (h *productHandler) GetLowStockProducts(c echo.Context) error {
+	page := 1
+	limit := 10
+
+	if p, err := strconv.Atoi(c.QueryParam("page")); err == nil && p > 0 {
+		page = p
+	}
+	if l, err := strconv.Atoi(c.QueryParam("limit")); err == nil && l > 0 {
+		limit = l
+	}
+
+	offset := (page - 1) * limit
+	products, total, err := h.Usecase.GetLowStockProducts(offset, limit)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	response := map[string]interface{}{
+		"data":  products,
+		"total": total,
+		"page":  page,
+		"limit": limit,
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
 func (h *productHandler) GetProductByID(c echo.Context) error {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
@@ -135,3 +165,77 @@ func (h *productHandler) DeleteProduct(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, res)
 }
+
+func (h *productHandler) GetStockHistory(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid product id"})
+	}
+
+	page := 1
+	limit := 20
+
+	if p, err := strconv.Atoi(c.QueryParam("page")); err == nil && p > 0 {
+		page = p
+	}
+	if l, err := strconv.Atoi(c.QueryParam("limit")); err == nil && l > 0 {
+		limit = l
+	}
+
+	offset := (page - 1) * limit
+	history, total, err := h.Usecase.GetStockHistory(uint(id), offset, limit)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	}
+
+	response := map[string]interface{}{
+		"product":      history.Product,
+		"ledger_stock": history.LedgerStock,
+		"data":         history.Movements,
+		"total":        total,
+		"page":         page,
+		"limit":        limit,
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+func (h *productHandler) AdjustStock(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid product id"})
+	}
+
+	var req domain.AdjustStockRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return utils.ValidationErrorResponse(c, err)
+	}
+
+	res, err := h.Usecase.AdjustStock(uint(id), req, middlewares.CurrentUserID(c))
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h *productHandler) BulkRestock(c echo.Context) error {
+	var req domain.BulkRestockRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return utils.ValidationErrorResponse(c, err)
+	}
+
+	res, err := h.Usecase.BulkRestock(req, middlewares.CurrentUserID(c))
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
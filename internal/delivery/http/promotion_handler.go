@@ -0,0 +1,126 @@
+package http
+
+import (
+	"butik/internal/delivery/http/middlewares"
+	"butik/internal/domain"
+	"butik/internal/usecase"
+	"butik/pkg/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type promotionHandler struct {
+	Usecase usecase.PromotionUsecase
+}
+
+func RegisterPromotionRoutes(e *echo.Echo, promotionUsecase usecase.PromotionUsecase) {
+	handler := &promotionHandler{Usecase: promotionUsecase}
+
+	// Protected
+	promotionGroup := e.Group("/promotions", middlewares.JWTMiddleware())
+	promotionGroup.GET("", handler.GetAllPromotions)
+	promotionGroup.GET("/:id", handler.GetPromotionByID)
+	promotionGroup.POST("", handler.CreatePromotion)
+	promotionGroup.PUT("/:id", handler.UpdatePromotion)
+	promotionGroup.DELETE("/:id", handler.DeletePromotion)
+}
+
+func (h *promotionHandler) CreatePromotion(c echo.Context) error {
+	var req domain.CreatePromotionRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return utils.ValidationErrorResponse(c, err)
+	}
+
+	res, err := h.Usecase.CreatePromotion(req)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, res)
+}
+
+func (h *promotionHandler) GetAllPromotions(c echo.Context) error {
+	pageStr := c.QueryParam("page")
+	limitStr := c.QueryParam("limit")
+
+	page := 1
+	limit := 10
+
+	if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
+		page = p
+	}
+	if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+		limit = l
+	}
+
+	offset := (page - 1) * limit
+	promotions, total, err := h.Usecase.GetAllPromotions(offset, limit)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	response := map[string]interface{}{
+		"data":  promotions,
+		"total": total,
+		"page":  page,
+		"limit": limit,
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+func (h *promotionHandler) GetPromotionByID(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid promotion id"})
+	}
+
+	res, err := h.Usecase.GetPromotionByID(uint(id))
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h *promotionHandler) UpdatePromotion(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid promotion id"})
+	}
+
+	var req domain.UpdatePromotionRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return utils.ValidationErrorResponse(c, err)
+	}
+
+	res, err := h.Usecase.UpdatePromotion(uint(id), req)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h *promotionHandler) DeletePromotion(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid promotion id"})
+	}
+
+	res, err := h.Usecase.DeletePromotion(uint(id))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,94 @@
+package http
+
+import (
+	"butik/internal/delivery/http/middlewares"
+	"butik/internal/domain"
+	"butik/internal/usecase"
+	"butik/pkg/utils"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type reportHandler struct {
+	Usecase usecase.ReportUsecase
+}
+
+func RegisterReportRoutes(e *echo.Echo, reportUsecase usecase.ReportUsecase) {
+	handler := &reportHandler{Usecase: reportUsecase}
+
+	// Protected
+	reportGroup := e.Group("/reports", middlewares.JWTMiddleware())
+	reportGroup.GET("/sales", handler.GetSalesReport)
+	reportGroup.GET("/top-products", handler.GetTopProducts)
+	reportGroup.GET("/top-categories", handler.GetTopCategories)
+	reportGroup.GET("/status-breakdown", handler.GetStatusBreakdown)
+}
+
+func (h *reportHandler) GetSalesReport(c echo.Context) error {
+	var query domain.ReportQuery
+	if err := c.Bind(&query); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid query parameters"})
+	}
+
+	if err := c.Validate(&query); err != nil {
+		return utils.ValidationErrorResponse(c, err)
+	}
+
+	res, err := h.Usecase.GetSalesReport(query)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h *reportHandler) GetTopProducts(c echo.Context) error {
+	var query domain.ReportQuery
+	if err := c.Bind(&query); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid query parameters"})
+	}
+
+	if err := c.Validate(&query); err != nil {
+		return utils.ValidationErrorResponse(c, err)
+	}
+
+	res, err := h.Usecase.GetTopProducts(query)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h *reportHandler) GetTopCategories(c echo.Context) error {
+	var query domain.ReportQuery
+	if err := c.Bind(&query); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid query parameters"})
+	}
+
+	if err := c.Validate(&query); err != nil {
+		return utils.ValidationErrorResponse(c, err)
+	}
+
+	res, err := h.Usecase.GetTopCategories(query)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h *reportHandler) GetStatusBreakdown(c echo.Context) error {
+	var query domain.ReportQuery
+	if err := c.Bind(&query); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid query parameters"})
+	}
+
+	if err := c.Validate(&query); err != nil {
+		return utils.ValidationErrorResponse(c, err)
+	}
+
+	res, err := h.Usecase.GetStatusBreakdown(query)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, res)
+}
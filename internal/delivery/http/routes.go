@@ -1,33 +1,45 @@
 package http
 
 import (
-	"butik/internal/repository"
 	"butik/internal/usecase"
 
 	"github.com/labstack/echo/v4"
-	"gorm.io/gorm"
 )
 
-func RegisterRoutes(e *echo.Echo, db *gorm.DB) {
-	// User
-	userRepo := repository.NewUserRepo(db)
-	userUsecase := usecase.NewUserUsecase(userRepo)
-	RegisterUserRoutes(e, userUsecase)
-
-	// Category
-	categoryRepo := repository.NewCategoryRepo(db)
-	categoryUsecase := usecase.NewCategoryUsecase(categoryRepo)
-	RegisterCategoryRoutes(e, categoryUsecase)
-
-	// Product
-	productRepo := repository.NewProductRepo(db)
-	productUsecase := usecase.NewProductUsecase(productRepo, categoryRepo)
-	RegisterProductRoutes(e, productUsecase)
+// Usecases usecase yang dipakai handler HTTP, dirakit di cmd/main.go
+type Usecases struct {
+	User            usecase.UserUsecase
+	Category        usecase.CategoryUsecase
+	Product         usecase.ProductUsecase
+	Voucher         usecase.VoucherUsecase
+	Promotion       usecase.PromotionUsecase
+	DeliveryRate    usecase.DeliveryRateUsecase
+	DeliveryZone    usecase.DeliveryZoneUsecase
+	MessageTemplate usecase.MessageTemplateUsecase
+	Notification    usecase.NotificationUsecase
+	Webhook         usecase.WebhookUsecase
+	Payment         usecase.PaymentUsecase
+	Order           usecase.OrderUsecase
+	Customer        usecase.CustomerUsecase
+	Report          usecase.ReportUsecase
+}
 
-	// Order
-	orderRepo := repository.NewOrderRepo(db)
-	orderUsecase := usecase.NewOrderUsecase(orderRepo, productRepo)
-	RegisterOrderRoutes(e, orderUsecase)
+// RegisterRoutes daftarkan semua route, idempotency dipasang di endpoint pembuatan order
+func RegisterRoutes(e *echo.Echo, uc Usecases, idempotency echo.MiddlewareFunc) {
+	RegisterUserRoutes(e, uc.User)
+	RegisterCategoryRoutes(e, uc.Category)
+	RegisterProductRoutes(e, uc.Product)
+	RegisterVoucherRoutes(e, uc.Voucher)
+	RegisterPromotionRoutes(e, uc.Promotion)
+	RegisterDeliveryRateRoutes(e, uc.DeliveryRate)
+	RegisterDeliveryZoneRoutes(e, uc.DeliveryZone)
+	RegisterMessageTemplateRoutes(e, uc.MessageTemplate)
+	RegisterNotificationRoutes(e, uc.Notification)
+	RegisterWebhookRoutes(e, uc.Webhook)
+	RegisterPaymentRoutes(e, uc.Payment)
+	RegisterOrderRoutes(e, uc.Order, idempotency)
+	RegisterCustomerRoutes(e, uc.Customer)
+	RegisterReportRoutes(e, uc.Report)
 
 	// static files (uploads)
 	e.Static("/uploads", "uploads")
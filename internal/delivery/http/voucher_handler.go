@@ -0,0 +1,126 @@
+package http
+
+import (
+	"butik/internal/delivery/http/middlewares"
+	"butik/internal/domain"
+	"butik/internal/usecase"
+	"butik/pkg/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type voucherHandler struct {
+	Usecase usecase.VoucherUsecase
+}
+
+func RegisterVoucherRoutes(e *echo.Echo, voucherUsecase usecase.VoucherUsecase) {
+	handler := &voucherHandler{Usecase: voucherUsecase}
+
+	// Protected
+	voucherGroup := e.Group("/vouchers", middlewares.JWTMiddleware())
+	voucherGroup.GET("", handler.GetAllVouchers)
+	voucherGroup.GET("/:id", handler.GetVoucherByID)
+	voucherGroup.POST("", handler.CreateVoucher)
+	voucherGroup.PUT("/:id", handler.UpdateVoucher)
+	voucherGroup.DELETE("/:id", handler.DeleteVoucher)
+}
+
+func (h *voucherHandler) CreateVoucher(c echo.Context) error {
+	var req domain.CreateVoucherRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return utils.ValidationErrorResponse(c, err)
+	}
+
+	res, err := h.Usecase.CreateVoucher(req)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, res)
+}
+
+func (h *voucherHandler) GetAllVouchers(c echo.Context) error {
+	pageStr := c.QueryParam("page")
+	limitStr := c.QueryParam("limit")
+
+	page := 1
+	limit := 10
+
+	if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
+		page = p
+	}
+	if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+		limit = l
+	}
+
+	offset := (page - 1) * limit
+	vouchers, total, err := h.Usecase.GetAllVouchers(offset, limit)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	response := map[string]interface{}{
+		"data":  vouchers,
+		"total": total,
+		"page":  page,
+		"limit": limit,
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+func (h *voucherHandler) GetVoucherByID(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid voucher id"})
+	}
+
+	res, err := h.Usecase.GetVoucherByID(uint(id))
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h *voucherHandler) UpdateVoucher(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid voucher id"})
+	}
+
+	var req domain.UpdateVoucherRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return utils.ValidationErrorResponse(c, err)
+	}
+
+	res, err := h.Usecase.UpdateVoucher(uint(id), req)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h *voucherHandler) DeleteVoucher(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid voucher id"})
+	}
+
+	res, err := h.Usecase.DeleteVoucher(uint(id))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
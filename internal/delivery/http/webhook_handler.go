@@ -0,0 +1,206 @@
+package http
+
+import (
+	"butik/internal/delivery/http/middlewares"
+	"butik/internal/domain"
+	"butik/internal/usecase"
+	"butik/pkg/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type webhookHandler struct {
+	Usecase usecase.WebhookUsecase
+}
+
+func RegisterWebhookRoutes(e *echo.Echo, webhookUsecase usecase.WebhookUsecase) {
+	handler := &webhookHandler{Usecase: webhookUsecase}
+
+	// Protected
+	webhookGroup := e.Group("/webhooks", middlewares.JWTMiddleware())
+	webhookGroup.GET("", handler.GetAllEndpoints)
+	webhookGroup.POST("", handler.CreateEndpoint)
+	webhookGroup.GET("/deliveries/:id", handler.GetDeliveryByID)
+	webhookGroup.POST("/deliveries/:id/redeliver", handler.Redeliver)
+	webhookGroup.GET("/:id", handler.GetEndpointByID)
+	webhookGroup.PUT("/:id", handler.UpdateEndpoint)
+	webhookGroup.DELETE("/:id", handler.DeleteEndpoint)
+	webhookGroup.GET("/:id/deliveries", handler.GetDeliveries)
+}
+
+func (h *webhookHandler) CreateEndpoint(c echo.Context) error {
+	var req domain.CreateWebhookEndpointRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return utils.ValidationErrorResponse(c, err)
+	}
+
+	res, err := h.Usecase.CreateEndpoint(req)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, res)
+}
+
+func (h *webhookHandler) GetAllEndpoints(c echo.Context) error {
+	page := 1
+	limit := 10
+
+	if p, err := strconv.Atoi(c.QueryParam("page")); err == nil && p > 0 {
+		page = p
+	}
+	if l, err := strconv.Atoi(c.QueryParam("limit")); err == nil && l > 0 {
+		limit = l
+	}
+
+	offset := (page - 1) * limit
+	endpoints, total, err := h.Usecase.GetAllEndpoints(offset, limit)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	response := map[string]interface{}{
+		"data":  endpoints,
+		"total": total,
+		"page":  page,
+		"limit": limit,
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+func (h *webhookHandler) GetEndpointByID(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid webhook endpoint id"})
+	}
+
+	endpoint, err := h.Usecase.GetEndpointByID(uint(id))
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, endpoint)
+}
+
+func (h *webhookHandler) UpdateEndpoint(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid webhook endpoint id"})
+	}
+
+	var req domain.UpdateWebhookEndpointRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return utils.ValidationErrorResponse(c, err)
+	}
+
+	res, err := h.Usecase.UpdateEndpoint(uint(id), req)
+	if err != nil {
+		if err.Error() == "webhook endpoint not found" {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h *webhookHandler) DeleteEndpoint(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid webhook endpoint id"})
+	}
+
+	res, err := h.Usecase.DeleteEndpoint(uint(id))
+	if err != nil {
+		if err.Error() == "webhook endpoint not found" {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h *webhookHandler) GetDeliveries(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid webhook endpoint id"})
+	}
+
+	page := 1
+	limit := 10
+
+	if p, err := strconv.Atoi(c.QueryParam("page")); err == nil && p > 0 {
+		page = p
+	}
+	if l, err := strconv.Atoi(c.QueryParam("limit")); err == nil && l > 0 {
+		limit = l
+	}
+
+	var query domain.WebhookDeliveryListQuery
+	if err := c.Bind(&query); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid query parameters"})
+	}
+
+	if err := c.Validate(&query); err != nil {
+		return utils.ValidationErrorResponse(c, err)
+	}
+
+	offset := (page - 1) * limit
+	deliveries, total, err := h.Usecase.GetDeliveries(uint(id), query, offset, limit)
+	if err != nil {
+		if err.Error() == "webhook endpoint not found" {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	response := map[string]interface{}{
+		"data":  deliveries,
+		"total": total,
+		"page":  page,
+		"limit": limit,
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+func (h *webhookHandler) GetDeliveryByID(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid webhook delivery id"})
+	}
+
+	delivery, err := h.Usecase.GetDeliveryByID(uint(id))
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, delivery)
+}
+
+func (h *webhookHandler) Redeliver(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid webhook delivery id"})
+	}
+
+	res, err := h.Usecase.Redeliver(uint(id))
+	if err != nil {
+		if err.Error() == "webhook delivery not found" {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusUnprocessableEntity, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
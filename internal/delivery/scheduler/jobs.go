@@ -0,0 +1,60 @@
+package scheduler
+
+import (
+	"butik/internal/repository"
+	"butik/internal/usecase"
+	"context"
+	"time"
+)
+
+// Usecases usecase yang dijalankan job berkala
+type Usecases struct {
+	Order        usecase.OrderUsecase
+	Product      usecase.ProductUsecase
+	Notification usecase.NotificationUsecase
+	Webhook      usecase.WebhookUsecase
+	Payment      usecase.PaymentUsecase
+	Customer     usecase.CustomerUsecase
+	// IdempotencyKeys key Idempotency-Key yang dihapus setelah lewat retention
+	IdempotencyKeys repository.IdempotencyRepo
+	// AutoCancelAge umur order pending sebelum dibatalkan, 0 = job tidak didaftarkan
+	AutoCancelAge time.Duration
+}
+
+// RegisterJobs daftarkan semua job berkala aplikasi
+func RegisterJobs(s *Scheduler, uc Usecases) {
+	s.Register("release_expired_stock_reservations", 5*time.Minute, func(ctx context.Context) error {
+		_, err := uc.Order.ReleaseExpiredReservations()
+		return err
+	})
+	s.Register("check_stock_ledger", 24*time.Hour, func(ctx context.Context) error {
+		_, err := uc.Product.CheckStockLedger()
+		return err
+	})
+	s.Register("deliver_notifications", time.Minute, func(ctx context.Context) error {
+		_, err := uc.Notification.DeliverDueNotifications()
+		return err
+	})
+	s.Register("deliver_webhooks", time.Minute, func(ctx context.Context) error {
+		_, err := uc.Webhook.DeliverDueWebhooks()
+		return err
+	})
+	s.Register("sync_pending_payments", 10*time.Minute, func(ctx context.Context) error {
+		_, err := uc.Payment.SyncPendingPayments()
+		return err
+	})
+	s.Register("link_orders_to_customers", time.Hour, func(ctx context.Context) error {
+		_, err := uc.Customer.LinkUnlinkedOrders()
+		return err
+	})
+	s.Register("purge_idempotency_keys", time.Hour, func(ctx context.Context) error {
+		_, err := uc.IdempotencyKeys.DeleteExpired(time.Now())
+		return err
+	})
+	if uc.AutoCancelAge > 0 {
+		s.Register("cancel_stale_pending_orders", 10*time.Minute, func(ctx context.Context) error {
+			_, err := uc.Order.CancelStalePendingOrders(uc.AutoCancelAge)
+			return err
+		})
+	}
+}
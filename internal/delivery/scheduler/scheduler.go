@@ -0,0 +1,115 @@
+package scheduler
+
+import (
+	"butik/internal/repository"
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	gonanoid "github.com/matoous/go-nanoid/v2"
+)
+
+const (
+	defaultPollInterval = 30 * time.Second
+	defaultJobLease     = 10 * time.Minute
+)
+
+type JobFunc func(ctx context.Context) error
+
+type job struct {
+	name     string
+	interval time.Duration
+	run      JobFunc
+}
+
+// Scheduler menjalankan job berkala di dalam proses API.
+// Jadwal dan lock disimpan di database sehingga beberapa instance tidak menjalankan job yang sama bersamaan.
+type Scheduler struct {
+	repo         repository.JobRepo
+	owner        string
+	pollInterval time.Duration
+	jobs         []job
+}
+
+func NewScheduler(repo repository.JobRepo) *Scheduler {
+	hostname, _ := os.Hostname()
+	suffix, _ := gonanoid.New(8)
+	return &Scheduler{
+		repo:         repo,
+		owner:        fmt.Sprintf("%s-%d-%s", hostname, os.Getpid(), suffix),
+		pollInterval: defaultPollInterval,
+	}
+}
+
+// Register tambahkan job, harus dipanggil sebelum Start
+func (s *Scheduler) Register(name string, interval time.Duration, run JobFunc) {
+	s.jobs = append(s.jobs, job{name: name, interval: interval, run: run})
+}
+
+// Start jalankan loop scheduler di goroutine terpisah sampai ctx selesai
+func (s *Scheduler) Start(ctx context.Context) {
+	for _, j := range s.jobs {
+		if err := s.repo.EnsureJob(j.name, time.Now()); err != nil {
+			log.Printf("scheduler: %s: %v", j.name, err)
+		}
+	}
+
+	go func() {
+		ticker := time.NewTicker(s.pollInterval)
+		defer ticker.Stop()
+
+		s.runDue(ctx)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.runDue(ctx)
+			}
+		}
+	}()
+}
+
+func (s *Scheduler) runDue(ctx context.Context) {
+	for _, j := range s.jobs {
+		if ctx.Err() != nil {
+			return
+		}
+
+		now := time.Now()
+		acquired, err := s.repo.AcquireJob(j.name, s.owner, now, now.Add(defaultJobLease))
+		if err != nil {
+			log.Printf("scheduler: %s: %v", j.name, err)
+			continue
+		}
+		if !acquired {
+			continue
+		}
+
+		var lastError string
+		if err := s.runJob(ctx, j); err != nil {
+			lastError = err.Error()
+			log.Printf("scheduler: %s failed: %v", j.name, err)
+		}
+
+		finishedAt := time.Now()
+		if err := s.repo.FinishJob(j.name, s.owner, finishedAt, finishedAt.Add(j.interval), lastError); err != nil {
+			log.Printf("scheduler: %s: %v", j.name, err)
+		}
+	}
+}
+
+// runJob panic di job tidak boleh menghentikan scheduler
+func (s *Scheduler) runJob(ctx context.Context, j job) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(ctx, defaultJobLease)
+	defer cancel()
+	return j.run(ctx)
+}
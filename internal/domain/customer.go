@@ -0,0 +1,63 @@
+package domain
+
+import "time"
+
+// Customer dikenali dari nomor WhatsApp yang sudah dinormalisasi
+type Customer struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	Whatsapp  string    `gorm:"uniqueIndex;not null" json:"whatsapp"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CustomerSummary customer beserta statistik order, total belanja hanya dari order success
+type CustomerSummary struct {
+	Customer
+	OrderCount  int
+	TotalSpent  float64
+	LastOrderAt *time.Time
+}
+
+// CustomerAddress alamat kirim yang pernah dipakai customer
+type CustomerAddress struct {
+	MapAddress  string
+	Latitude    float64
+	Longitude   float64
+	AddressNote string
+	OrderCount  int
+	LastUsedAt  time.Time
+}
+
+// Request DTOs
+type CustomerListQuery struct {
+	Search    string `query:"search" validate:"max=100"`
+	SortBy    string `query:"sort_by" validate:"omitempty,oneof=last_order_at total_spent order_count name"`
+	SortOrder string `query:"sort_order" validate:"omitempty,oneof=asc desc"`
+}
+
+// Response DTOs
+type CustomerResponse struct {
+	ID          uint    `json:"id"`
+	Whatsapp    string  `json:"whatsapp"`
+	Name        string  `json:"name"`
+	OrderCount  int     `json:"order_count"`
+	TotalSpent  float64 `json:"total_spent"`
+	LastOrderAt *string `json:"last_order_at"`
+	CreatedAt   string  `json:"created_at"`
+}
+
+type CustomerAddressResponse struct {
+	MapAddress  string  `json:"map_address"`
+	Latitude    float64 `json:"latitude"`
+	Longitude   float64 `json:"longitude"`
+	AddressNote string  `json:"address_note"`
+	OrderCount  int     `json:"order_count"`
+	LastUsedAt  string  `json:"last_used_at"`
+}
+
+type CustomerDetailResponse struct {
+	Customer  CustomerResponse          `json:"customer"`
+	Addresses []CustomerAddressResponse `json:"addresses"`
+	Orders    []*OrderResponse          `json:"orders"`
+}
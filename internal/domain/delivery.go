@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// DeliveryConfig lokasi toko dan aturan ongkir yang dibaca dari env
+type DeliveryConfig struct {
+	OriginLatitude   float64
+	OriginLongitude  float64
+	MaxRadiusKm      float64
+	FreeAboveTotal   float64
+	OriginConfigured bool
+	OriginCity       string
+	Location         *time.Location
+}
+
+// DeliveryRate satu band jarak ongkir: fee = base_fee + per_km_fee * ceil(jarak)
+type DeliveryRate struct {
+	ID            uint      `gorm:"primaryKey" json:"id"`
+	MaxDistanceKm float64   `gorm:"uniqueIndex;not null" json:"max_distance_km"`
+	BaseFee       float64   `gorm:"not null" json:"base_fee"`
+	PerKmFee      float64   `gorm:"not null;default:0" json:"per_km_fee"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// Request DTOs
+type CreateDeliveryRateRequest struct {
+	MaxDistanceKm float64 `json:"max_distance_km" validate:"required,gt=0,lte=1000"`
+	BaseFee       float64 `json:"base_fee" validate:"gte=0,lte=999999999"`
+	PerKmFee      float64 `json:"per_km_fee" validate:"gte=0,lte=999999999"`
+}
+
+type UpdateDeliveryRateRequest struct {
+	MaxDistanceKm float64 `json:"max_distance_km" validate:"required,gt=0,lte=1000"`
+	BaseFee       float64 `json:"base_fee" validate:"gte=0,lte=999999999"`
+	PerKmFee      float64 `json:"per_km_fee" validate:"gte=0,lte=999999999"`
+}
+
+// Response DTOs
+type DeliveryRateResponse struct {
+	ID            uint    `json:"id"`
+	MaxDistanceKm float64 `json:"max_distance_km"`
+	BaseFee       float64 `json:"base_fee"`
+	PerKmFee      float64 `json:"per_km_fee"`
+	CreatedAt     string  `json:"created_at"`
+}
+
+type CreateDeliveryRateResponse struct {
+	Message      string               `json:"message"`
+	DeliveryRate DeliveryRateResponse `json:"delivery_rate"`
+}
+
+type UpdateDeliveryRateResponse struct {
+	Message      string               `json:"message"`
+	DeliveryRate DeliveryRateResponse `json:"delivery_rate"`
+}
+
+type DeleteDeliveryRateResponse struct {
+	Message string `json:"message"`
+}
+
+// DeliveryZone area pengiriman berbentuk polygon GeoJSON dengan ongkir dan hari kirim sendiri
+type DeliveryZone struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	Name        string    `gorm:"unique;not null" json:"name"`
+	Fee         float64   `gorm:"not null" json:"fee"`
+	AllowedDays string    `gorm:"not null" json:"allowed_days"`
+	IsActive    bool      `gorm:"not null" json:"is_active"`
+	Geometry    string    `gorm:"type:jsonb;not null" json:"geometry"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type CreateDeliveryZoneRequest struct {
+	Name        string          `json:"name" validate:"required,min=2,max=100"`
+	Fee         float64         `json:"fee" validate:"gte=0,lte=999999999"`
+	AllowedDays []string        `json:"allowed_days" validate:"required,min=1,max=7,dive,oneof=mon tue wed thu fri sat sun"`
+	IsActive    *bool           `json:"is_active"`
+	Geometry    json.RawMessage `json:"geometry" validate:"required"`
+}
+
+type UpdateDeliveryZoneRequest struct {
+	Name        string          `json:"name" validate:"required,min=2,max=100"`
+	Fee         float64         `json:"fee" validate:"gte=0,lte=999999999"`
+	AllowedDays []string        `json:"allowed_days" validate:"required,min=1,max=7,dive,oneof=mon tue wed thu fri sat sun"`
+	IsActive    *bool           `json:"is_active"`
+	Geometry    json.RawMessage `json:"geometry" validate:"required"`
+}
+
+type DeliveryZoneResponse struct {
+	ID          uint            `json:"id"`
+	Name        string          `json:"name"`
+	Fee         float64         `json:"fee"`
+	AllowedDays []string        `json:"allowed_days"`
+	IsActive    bool            `json:"is_active"`
+	Geometry    json.RawMessage `json:"geometry,omitempty"`
+	CreatedAt   string          `json:"created_at"`
+}
+
+type DeliveryZoneLookupResponse struct {
+	Zone             DeliveryZoneResponse `json:"zone"`
+	NextDeliveryDate string               `json:"next_delivery_date"`
+}
+
+type CreateDeliveryZoneResponse struct {
+	Message      string               `json:"message"`
+	DeliveryZone DeliveryZoneResponse `json:"delivery_zone"`
+}
+
+type UpdateDeliveryZoneResponse struct {
+	Message      string               `json:"message"`
+	DeliveryZone DeliveryZoneResponse `json:"delivery_zone"`
+}
+
+type DeleteDeliveryZoneResponse struct {
+	Message string `json:"message"`
+}
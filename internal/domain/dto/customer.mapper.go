@@ -0,0 +1,41 @@
+package dto
+
+import (
+	"butik/internal/domain"
+	"time"
+)
+
+func ToCustomerResponse(customer *domain.CustomerSummary) *domain.CustomerResponse {
+	return &domain.CustomerResponse{
+		ID:          customer.ID,
+		Whatsapp:    customer.Whatsapp,
+		Name:        customer.Name,
+		OrderCount:  customer.OrderCount,
+		TotalSpent:  customer.TotalSpent,
+		LastOrderAt: formatOptionalTime(customer.LastOrderAt),
+		CreatedAt:   customer.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func ToCustomerResponses(customers []domain.CustomerSummary) []*domain.CustomerResponse {
+	responses := make([]*domain.CustomerResponse, len(customers))
+	for i := range customers {
+		responses[i] = ToCustomerResponse(&customers[i])
+	}
+	return responses
+}
+
+func ToCustomerAddressResponses(addresses []domain.CustomerAddress) []domain.CustomerAddressResponse {
+	responses := make([]domain.CustomerAddressResponse, len(addresses))
+	for i, address := range addresses {
+		responses[i] = domain.CustomerAddressResponse{
+			MapAddress:  address.MapAddress,
+			Latitude:    address.Latitude,
+			Longitude:   address.Longitude,
+			AddressNote: address.AddressNote,
+			OrderCount:  address.OrderCount,
+			LastUsedAt:  address.LastUsedAt.Format(time.RFC3339),
+		}
+	}
+	return responses
+}
@@ -0,0 +1,49 @@
+package dto
+
+import (
+	"butik/internal/domain"
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+func ToDeliveryRateResponse(rate *domain.DeliveryRate) *domain.DeliveryRateResponse {
+	return &domain.DeliveryRateResponse{
+		ID:            rate.ID,
+		MaxDistanceKm: rate.MaxDistanceKm,
+		BaseFee:       rate.BaseFee,
+		PerKmFee:      rate.PerKmFee,
+		CreatedAt:     rate.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func ToDeliveryRateResponses(rates []domain.DeliveryRate) []*domain.DeliveryRateResponse {
+	responses := make([]*domain.DeliveryRateResponse, len(rates))
+	for i, rate := range rates {
+		responses[i] = ToDeliveryRateResponse(&rate)
+	}
+	return responses
+}
+
+func ToDeliveryZoneResponse(zone *domain.DeliveryZone, withGeometry bool) *domain.DeliveryZoneResponse {
+	res := &domain.DeliveryZoneResponse{
+		ID:          zone.ID,
+		Name:        zone.Name,
+		Fee:         zone.Fee,
+		AllowedDays: strings.Split(zone.AllowedDays, ","),
+		IsActive:    zone.IsActive,
+		CreatedAt:   zone.CreatedAt.Format(time.RFC3339),
+	}
+	if withGeometry {
+		res.Geometry = json.RawMessage(zone.Geometry)
+	}
+	return res
+}
+
+func ToDeliveryZoneResponses(zones []domain.DeliveryZone) []*domain.DeliveryZoneResponse {
+	responses := make([]*domain.DeliveryZoneResponse, len(zones))
+	for i, zone := range zones {
+		responses[i] = ToDeliveryZoneResponse(&zone, true)
+	}
+	return responses
+}
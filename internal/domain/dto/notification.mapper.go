@@ -0,0 +1,47 @@
+package dto
+
+import (
+	"butik/internal/domain"
+	"time"
+)
+
+func ToNotificationResponse(notification *domain.Notification) *domain.NotificationResponse {
+	response := &domain.NotificationResponse{
+		ID:          notification.ID,
+		OrderID:     notification.OrderID,
+		Event:       notification.Event,
+		Channel:     notification.Channel,
+		Provider:    notification.Provider,
+		Recipient:   notification.Recipient,
+		Subject:     notification.Subject,
+		Body:        notification.Body,
+		Status:      notification.Status,
+		Attempts:    notification.Attempts,
+		LastError:   notification.LastError,
+		SentAt:      formatOptionalTime(notification.SentAt),
+		AttemptLogs: make([]domain.NotificationAttemptResponse, len(notification.AttemptLogs)),
+		CreatedAt:   notification.CreatedAt.Format(time.RFC3339),
+	}
+	// Jadwal berikutnya hanya relevan selama masih menunggu kirim
+	if notification.Status == domain.NotificationStatusPending {
+		response.NextAttemptAt = formatOptionalTime(&notification.NextAttemptAt)
+	}
+	for i, attempt := range notification.AttemptLogs {
+		response.AttemptLogs[i] = domain.NotificationAttemptResponse{
+			Attempt:    attempt.Attempt,
+			Success:    attempt.Success,
+			Error:      attempt.Error,
+			DurationMs: attempt.DurationMs,
+			CreatedAt:  attempt.CreatedAt.Format(time.RFC3339),
+		}
+	}
+	return response
+}
+
+func ToNotificationResponses(notifications []domain.Notification) []*domain.NotificationResponse {
+	responses := make([]*domain.NotificationResponse, len(notifications))
+	for i := range notifications {
+		responses[i] = ToNotificationResponse(&notifications[i])
+	}
+	return responses
+}
@@ -23,20 +23,59 @@ func ToOrderItemResponses(items []domain.OrderItem) []domain.OrderItemResponse {
 	return responses
 }
 
+func ToOrderDiscountResponses(discounts []domain.OrderDiscount) []domain.OrderDiscountResponse {
+	responses := make([]domain.OrderDiscountResponse, len(discounts))
+	for i, discount := range discounts {
+		responses[i] = domain.OrderDiscountResponse{
+			Type:        discount.Type,
+			Code:        discount.Code,
+			Description: discount.Description,
+			Amount:      discount.Amount,
+		}
+	}
+	return responses
+}
+
 func ToOrderResponse(order *domain.Order) *domain.OrderResponse {
 	return &domain.OrderResponse{
-		ID:             order.ID,
-		CustomerName:   order.CustomerName,
-		Whatsapp:       order.Whatsapp,
-		MapAddress:     order.MapAddress,
-		Latitude:       order.Latitude,
-		Longitude:      order.Longitude,
-		AddressNote:    order.AddressNote,
-		TotalPrice:     order.TotalPrice,
-		ProofOfPayment: order.ProofOfPayment,
-		Status:         order.Status,
-		OrderItems:     ToOrderItemResponses(order.OrderItems),
-		CreatedAt:      order.CreatedAt.Format(time.RFC3339),
+		ID:               order.ID,
+		OrderNumber:      order.OrderNumber,
+		CustomerID:       order.CustomerID,
+		CustomerName:     order.CustomerName,
+		Whatsapp:         order.Whatsapp,
+		Email:            order.Email,
+		Language:         order.Language,
+		MapAddress:       order.MapAddress,
+		Latitude:         order.Latitude,
+		Longitude:        order.Longitude,
+		AddressNote:      order.AddressNote,
+		FulfilmentMethod: order.FulfilmentMethod,
+		PickupCode:       order.PickupCode,
+		CollectedAt:      formatOptionalTime(order.CollectedAt),
+		Subtotal:         order.Subtotal,
+		DiscountTotal:    order.DiscountTotal,
+		DistanceKm:       order.DistanceKm,
+		DeliveryFee:      order.DeliveryFee,
+		DeliveryZone:     order.DeliveryZone,
+		DeliveryDate:     formatOptionalDate(order.DeliveryDate),
+		DestinationCity:  order.DestinationCity,
+		Courier:          order.Courier,
+		CourierService:   order.CourierService,
+		ShippingWeight:   order.ShippingWeight,
+		AirwayBill:       order.AirwayBill,
+		TrackingStatus:   order.TrackingStatus,
+		TotalPrice:       order.TotalPrice,
+		VoucherCode:      order.VoucherCode,
+		PaymentMethod:    order.PaymentMethod,
+		ProofOfPayment:   order.ProofOfPayment,
+		PaidAt:           formatOptionalTime(order.PaidAt),
+		Payments:         ToPaymentResponses(order.Payments),
+		Status:           order.Status,
+		CancelReason:     order.CancelReason,
+		CancelledAt:      formatOptionalTime(order.CancelledAt),
+		OrderItems:       ToOrderItemResponses(order.OrderItems),
+		Discounts:        ToOrderDiscountResponses(order.Discounts),
+		CreatedAt:        order.CreatedAt.Format(time.RFC3339),
 	}
 }
 
@@ -47,3 +86,11 @@ func ToOrderResponses(orders []domain.Order) []*domain.OrderResponse {
 	}
 	return responses
 }
+
+func formatOptionalDate(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	formatted := t.Format("2006-01-02")
+	return &formatted
+}
@@ -0,0 +1,30 @@
+package dto
+
+import (
+	"butik/internal/domain"
+	"time"
+)
+
+func ToPaymentResponse(payment *domain.Payment) *domain.PaymentResponse {
+	return &domain.PaymentResponse{
+		Provider:   payment.Provider,
+		Reference:  payment.Reference,
+		Amount:     payment.Amount,
+		Status:     payment.Status,
+		PaymentURL: payment.PaymentURL,
+		ExpiresAt:  formatOptionalTime(payment.ExpiresAt),
+		PaidAt:     formatOptionalTime(payment.PaidAt),
+		CreatedAt:  payment.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func ToPaymentResponses(payments []domain.Payment) []domain.PaymentResponse {
+	if len(payments) == 0 {
+		return nil
+	}
+	responses := make([]domain.PaymentResponse, len(payments))
+	for i := range payments {
+		responses[i] = *ToPaymentResponse(&payments[i])
+	}
+	return responses
+}
@@ -7,14 +7,18 @@ import (
 
 func ToProductResponse(prod *domain.Product) *domain.ProductResponse {
 	return &domain.ProductResponse{
-		ID:          prod.ID,
-		Name:        prod.Name,
-		Description: prod.Description,
-		Price:       prod.Price,
-		Stock:       prod.Stock,
-		Category:    *ToCategoryResponse(&prod.Category),
-		ImageURL:    prod.ImageURL,
-		CreatedAt:   prod.CreatedAt.Format(time.RFC3339),
+		ID:                prod.ID,
+		Name:              prod.Name,
+		Description:       prod.Description,
+		Price:             prod.Price,
+		Stock:             prod.Stock,
+		Reserved:          prod.Reserved,
+		Available:         prod.AvailableStock(),
+		Weight:            prod.Weight,
+		LowStockThreshold: prod.LowStockThreshold,
+		Category:          *ToCategoryResponse(&prod.Category),
+		ImageURL:          prod.ImageURL,
+		CreatedAt:         prod.CreatedAt.Format(time.RFC3339),
 	}
 }
 
@@ -0,0 +1,50 @@
+package dto
+
+import (
+	"butik/internal/domain"
+	"time"
+)
+
+func ToPromotionResponse(promo *domain.Promotion) *domain.PromotionResponse {
+	categoryIDs := make([]uint, len(promo.Categories))
+	for i, cat := range promo.Categories {
+		categoryIDs[i] = cat.ID
+	}
+
+	productIDs := make([]uint, len(promo.Products))
+	for i, prod := range promo.Products {
+		productIDs[i] = prod.ID
+	}
+
+	return &domain.PromotionResponse{
+		ID:              promo.ID,
+		Name:            promo.Name,
+		Description:     promo.Description,
+		Type:            promo.Type,
+		Priority:        promo.Priority,
+		Stackable:       promo.Stackable,
+		IsActive:        promo.IsActive,
+		StartsAt:        formatOptionalTime(promo.StartsAt),
+		EndsAt:          formatOptionalTime(promo.EndsAt),
+		MinQuantity:     promo.MinQuantity,
+		MinSubtotal:     promo.MinSubtotal,
+		DiscountValue:   promo.DiscountValue,
+		MaxDiscount:     promo.MaxDiscount,
+		BuyQuantity:     promo.BuyQuantity,
+		GetQuantity:     promo.GetQuantity,
+		RewardProductID: promo.RewardProductID,
+		RewardQuantity:  promo.RewardQuantity,
+		BundlePrice:     promo.BundlePrice,
+		CategoryIDs:     categoryIDs,
+		ProductIDs:      productIDs,
+		CreatedAt:       promo.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func ToPromotionResponses(promos []domain.Promotion) []*domain.PromotionResponse {
+	responses := make([]*domain.PromotionResponse, len(promos))
+	for i, promo := range promos {
+		responses[i] = ToPromotionResponse(&promo)
+	}
+	return responses
+}
@@ -0,0 +1,28 @@
+package dto
+
+import (
+	"butik/internal/domain"
+	"time"
+)
+
+func ToStockMovementResponse(movement *domain.StockMovement) *domain.StockMovementResponse {
+	return &domain.StockMovementResponse{
+		ID:         movement.ID,
+		ProductID:  movement.ProductID,
+		Delta:      movement.Delta,
+		StockAfter: movement.StockAfter,
+		Reason:     movement.Reason,
+		OrderID:    movement.OrderID,
+		UserID:     movement.UserID,
+		Note:       movement.Note,
+		CreatedAt:  movement.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func ToStockMovementResponses(movements []domain.StockMovement) []*domain.StockMovementResponse {
+	responses := make([]*domain.StockMovementResponse, len(movements))
+	for i, movement := range movements {
+		responses[i] = ToStockMovementResponse(&movement)
+	}
+	return responses
+}
@@ -0,0 +1,53 @@
+package dto
+
+import (
+	"butik/internal/domain"
+	"time"
+)
+
+func ToVoucherResponse(voucher *domain.Voucher) *domain.VoucherResponse {
+	categoryIDs := make([]uint, len(voucher.Categories))
+	for i, cat := range voucher.Categories {
+		categoryIDs[i] = cat.ID
+	}
+
+	productIDs := make([]uint, len(voucher.Products))
+	for i, prod := range voucher.Products {
+		productIDs[i] = prod.ID
+	}
+
+	return &domain.VoucherResponse{
+		ID:                    voucher.ID,
+		Code:                  voucher.Code,
+		Description:           voucher.Description,
+		Type:                  voucher.Type,
+		Value:                 voucher.Value,
+		MinOrderValue:         voucher.MinOrderValue,
+		MaxDiscount:           voucher.MaxDiscount,
+		StartsAt:              formatOptionalTime(voucher.StartsAt),
+		EndsAt:                formatOptionalTime(voucher.EndsAt),
+		UsageLimit:            voucher.UsageLimit,
+		UsageLimitPerWhatsapp: voucher.UsageLimitPerWhatsapp,
+		UsedCount:             voucher.UsedCount,
+		IsActive:              voucher.IsActive,
+		CategoryIDs:           categoryIDs,
+		ProductIDs:            productIDs,
+		CreatedAt:             voucher.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func ToVoucherResponses(vouchers []domain.Voucher) []*domain.VoucherResponse {
+	responses := make([]*domain.VoucherResponse, len(vouchers))
+	for i, voucher := range vouchers {
+		responses[i] = ToVoucherResponse(&voucher)
+	}
+	return responses
+}
+
+func formatOptionalTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	formatted := t.Format(time.RFC3339)
+	return &formatted
+}
@@ -0,0 +1,111 @@
+package dto
+
+import (
+	"butik/internal/domain"
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+func ToWebhookEndpointResponse(endpoint *domain.WebhookEndpoint) *domain.WebhookEndpointResponse {
+	return &domain.WebhookEndpointResponse{
+		ID:          endpoint.ID,
+		URL:         endpoint.URL,
+		Description: endpoint.Description,
+		Events:      strings.Split(endpoint.Events, ","),
+		IsActive:    endpoint.IsActive,
+		CreatedAt:   endpoint.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   endpoint.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func ToWebhookEndpointResponses(endpoints []domain.WebhookEndpoint) []*domain.WebhookEndpointResponse {
+	responses := make([]*domain.WebhookEndpointResponse, len(endpoints))
+	for i := range endpoints {
+		responses[i] = ToWebhookEndpointResponse(&endpoints[i])
+	}
+	return responses
+}
+
+func ToWebhookOrder(order *domain.Order) *domain.WebhookOrder {
+	response := ToOrderResponse(order)
+	return &domain.WebhookOrder{
+		ID:               response.ID,
+		OrderNumber:      response.OrderNumber,
+		CustomerID:       response.CustomerID,
+		CustomerName:     response.CustomerName,
+		Whatsapp:         response.Whatsapp,
+		Email:            response.Email,
+		Language:         response.Language,
+		MapAddress:       response.MapAddress,
+		Latitude:         response.Latitude,
+		Longitude:        response.Longitude,
+		AddressNote:      response.AddressNote,
+		FulfilmentMethod: response.FulfilmentMethod,
+		CollectedAt:      response.CollectedAt,
+		Subtotal:         response.Subtotal,
+		DiscountTotal:    response.DiscountTotal,
+		DistanceKm:       response.DistanceKm,
+		DeliveryFee:      response.DeliveryFee,
+		DeliveryZone:     response.DeliveryZone,
+		DeliveryDate:     response.DeliveryDate,
+		DestinationCity:  response.DestinationCity,
+		Courier:          response.Courier,
+		CourierService:   response.CourierService,
+		ShippingWeight:   response.ShippingWeight,
+		AirwayBill:       response.AirwayBill,
+		TrackingStatus:   response.TrackingStatus,
+		TotalPrice:       response.TotalPrice,
+		VoucherCode:      response.VoucherCode,
+		PaymentMethod:    response.PaymentMethod,
+		ProofOfPayment:   response.ProofOfPayment,
+		PaidAt:           response.PaidAt,
+		Payments:         response.Payments,
+		Status:           response.Status,
+		CancelReason:     response.CancelReason,
+		CancelledAt:      response.CancelledAt,
+		OrderItems:       response.OrderItems,
+		Discounts:        response.Discounts,
+		CreatedAt:        response.CreatedAt,
+	}
+}
+
+func ToWebhookDeliveryResponse(delivery *domain.WebhookDelivery) *domain.WebhookDeliveryResponse {
+	response := &domain.WebhookDeliveryResponse{
+		ID:             delivery.ID,
+		EndpointID:     delivery.EndpointID,
+		EventID:        delivery.EventID,
+		Event:          delivery.Event,
+		Payload:        json.RawMessage(delivery.Payload),
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		ResponseStatus: delivery.ResponseStatus,
+		LastError:      delivery.LastError,
+		DeliveredAt:    formatOptionalTime(delivery.DeliveredAt),
+		AttemptLogs:    make([]domain.WebhookAttemptResponse, len(delivery.AttemptLogs)),
+		CreatedAt:      delivery.CreatedAt.Format(time.RFC3339),
+	}
+	// Jadwal berikutnya hanya relevan selama masih menunggu kirim
+	if delivery.Status == domain.WebhookDeliveryPending {
+		response.NextAttemptAt = formatOptionalTime(&delivery.NextAttemptAt)
+	}
+	for i, attempt := range delivery.AttemptLogs {
+		response.AttemptLogs[i] = domain.WebhookAttemptResponse{
+			Attempt:        attempt.Attempt,
+			ResponseStatus: attempt.ResponseStatus,
+			ResponseBody:   attempt.ResponseBody,
+			Error:          attempt.Error,
+			DurationMs:     attempt.DurationMs,
+			CreatedAt:      attempt.CreatedAt.Format(time.RFC3339),
+		}
+	}
+	return response
+}
+
+func ToWebhookDeliveryResponses(deliveries []domain.WebhookDelivery) []*domain.WebhookDeliveryResponse {
+	responses := make([]*domain.WebhookDeliveryResponse, len(deliveries))
+	for i := range deliveries {
+		responses[i] = ToWebhookDeliveryResponse(&deliveries[i])
+	}
+	return responses
+}
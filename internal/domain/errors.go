@@ -0,0 +1,45 @@
+package domain
+
+import "errors"
+
+var (
+	ErrOrderNotFound   = errors.New("order not found")
+	ErrPaymentNotFound = errors.New("payment not found")
+	// ErrPaymentFailed tagihan tidak bisa dibuat (provider atau penyimpanan gagal)
+	ErrPaymentFailed           = errors.New("failed to create payment")
+	ErrInvalidPaymentSignature = errors.New("invalid payment callback signature")
+	// ErrTrackingUnavailable kurir tidak punya data tracking untuk resi tersebut
+	ErrTrackingUnavailable = errors.New("tracking unavailable")
+
+	// ErrOrderRejected dicocokkan dengan errors.Is untuk error karena isi order atau cart
+	// (stok, voucher, pengiriman), bukan kegagalan server
+	ErrOrderRejected = errors.New("order rejected")
+	// ErrPaymentRejected dicocokkan dengan errors.Is untuk callback atau simulasi pembayaran yang tidak valid
+	ErrPaymentRejected = errors.New("payment rejected")
+
+	ErrPaymentGatewayUnavailable = RejectOrder("payment gateway is not available")
+)
+
+// rejection error dengan pesan untuk client yang cocok dengan errors.Is(err, kind)
+type rejection struct {
+	kind    error
+	message string
+}
+
+func (e *rejection) Error() string {
+	return e.message
+}
+
+func (e *rejection) Is(target error) bool {
+	return target == e.kind
+}
+
+// RejectOrder error penolakan order dengan pesan untuk client
+func RejectOrder(message string) error {
+	return &rejection{kind: ErrOrderRejected, message: message}
+}
+
+// RejectPayment error penolakan callback atau simulasi pembayaran dengan pesan untuk client
+func RejectPayment(message string) error {
+	return &rejection{kind: ErrPaymentRejected, message: message}
+}
@@ -0,0 +1,24 @@
+package domain
+
+import "time"
+
+type IdempotencyStatus string
+
+const (
+	IdempotencyStatusProcessing IdempotencyStatus = "processing"
+	IdempotencyStatusCompleted  IdempotencyStatus = "completed"
+)
+
+// IdempotencyKey menyimpan response pertama dari request dengan header Idempotency-Key
+type IdempotencyKey struct {
+	Key          string            `gorm:"primaryKey;size:255" json:"key"`
+	Scope        string            `gorm:"primaryKey;size:100" json:"scope"`
+	RequestHash  string            `gorm:"not null" json:"request_hash"`
+	Status       IdempotencyStatus `gorm:"not null" json:"status"`
+	StatusCode   int               `json:"status_code"`
+	ResponseBody string            `gorm:"type:text" json:"response_body"`
+	// LockedUntil lease selama request diproses, setelah lewat key boleh diambil retry (misalnya server crash)
+	LockedUntil *time.Time `json:"locked_until"`
+	ExpiresAt   time.Time  `gorm:"index" json:"expires_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
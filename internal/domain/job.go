@@ -0,0 +1,14 @@
+package domain
+
+import "time"
+
+// ScheduledJob status job background, baris ini juga dipakai sebagai lock antar instance
+type ScheduledJob struct {
+	Name        string     `gorm:"primaryKey;size:100" json:"name"`
+	NextRunAt   time.Time  `gorm:"index" json:"next_run_at"`
+	LockedBy    string     `json:"locked_by"`
+	LockedUntil *time.Time `json:"locked_until"`
+	LastRunAt   *time.Time `json:"last_run_at"`
+	LastError   string     `gorm:"type:text" json:"last_error"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
@@ -0,0 +1,64 @@
+package domain
+
+import "time"
+
+const (
+	LanguageIndonesian = "id"
+	LanguageEnglish    = "en"
+)
+
+// MessageTemplate pesan notifikasi yang diubah admin, menggantikan template bawaan
+type MessageTemplate struct {
+	ID        uint              `gorm:"primaryKey" json:"id"`
+	Event     NotificationEvent `gorm:"not null;uniqueIndex:idx_message_templates_event_language" json:"event"`
+	Language  string            `gorm:"size:5;not null;uniqueIndex:idx_message_templates_event_language" json:"language"`
+	Subject   string            `json:"subject"`
+	Body      string            `gorm:"type:text;not null" json:"body"`
+	UpdatedBy *uint             `json:"updated_by"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+// TemplateVariable variabel yang tersedia di template, dipakai sebagai {{.Name}}
+type TemplateVariable struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// Request DTOs
+type SaveMessageTemplateRequest struct {
+	Subject string `json:"subject" validate:"max=200"`
+	Body    string `json:"body" validate:"required,max=4000"`
+}
+
+// PreviewMessageTemplateRequest subject/body kosong berarti pakai template yang berlaku
+type PreviewMessageTemplateRequest struct {
+	Event    NotificationEvent `json:"event" validate:"required,oneof=order.created order.payment_verified order.rejected order.shipped order.payment_reminder"`
+	Language string            `json:"language" validate:"omitempty,oneof=id en"`
+	OrderID  string            `json:"order_id" validate:"required,max=50"`
+	Subject  string            `json:"subject" validate:"max=200"`
+	Body     string            `json:"body" validate:"max=4000"`
+}
+
+// Response DTOs
+type MessageTemplateResponse struct {
+	Event     NotificationEvent `json:"event"`
+	Language  string            `json:"language"`
+	Subject   string            `json:"subject"`
+	Body      string            `json:"body"`
+	IsDefault bool              `json:"is_default"`
+	UpdatedAt *string           `json:"updated_at"`
+}
+
+type SaveMessageTemplateResponse struct {
+	Message  string                  `json:"message"`
+	Template MessageTemplateResponse `json:"template"`
+}
+
+type PreviewMessageTemplateResponse struct {
+	Event    NotificationEvent `json:"event"`
+	Language string            `json:"language"`
+	OrderID  string            `json:"order_id"`
+	Subject  string            `json:"subject"`
+	Body     string            `json:"body"`
+}
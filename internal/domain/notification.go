@@ -0,0 +1,102 @@
+package domain
+
+import "time"
+
+type NotificationEvent string
+
+const (
+	NotificationOrderCreated    NotificationEvent = "order.created"
+	NotificationPaymentVerified NotificationEvent = "order.payment_verified"
+	NotificationOrderRejected   NotificationEvent = "order.rejected"
+	NotificationOrderShipped    NotificationEvent = "order.shipped"
+	// Tidak dikirim otomatis, hanya untuk link WhatsApp admin ke customer
+	NotificationPaymentReminder NotificationEvent = "order.payment_reminder"
+)
+
+type NotificationChannel string
+
+const (
+	NotificationChannelWhatsapp NotificationChannel = "whatsapp"
+	NotificationChannelEmail    NotificationChannel = "email"
+)
+
+type NotificationStatus string
+
+const (
+	NotificationStatusPending NotificationStatus = "pending"
+	NotificationStatusSent    NotificationStatus = "sent"
+	NotificationStatusFailed  NotificationStatus = "failed"
+)
+
+// Notification pesan ke customer untuk satu event order di satu channel.
+// Pesan sudah dirender saat dibuat, pengiriman diulang sampai MaxAttempts.
+type Notification struct {
+	ID            uint                  `gorm:"primaryKey" json:"id"`
+	OrderID       string                `gorm:"index" json:"order_id"`
+	Event         NotificationEvent     `gorm:"index" json:"event"`
+	Channel       NotificationChannel   `json:"channel"`
+	Provider      string                `json:"provider"`
+	Recipient     string                `json:"recipient"`
+	Subject       string                `json:"subject"`
+	Body          string                `gorm:"type:text" json:"body"`
+	Status        NotificationStatus    `gorm:"default:pending;index:idx_notifications_status_next_attempt,priority:1" json:"status"`
+	Attempts      int                   `json:"attempts"`
+	NextAttemptAt time.Time             `gorm:"index:idx_notifications_status_next_attempt,priority:2" json:"next_attempt_at"`
+	LockedUntil   *time.Time            `json:"locked_until"`
+	LastError     string                `json:"last_error"`
+	SentAt        *time.Time            `json:"sent_at"`
+	AttemptLogs   []NotificationAttempt `gorm:"foreignKey:NotificationID;constraint:OnDelete:CASCADE;" json:"attempt_logs"`
+	CreatedAt     time.Time             `json:"created_at"`
+	UpdatedAt     time.Time             `json:"updated_at"`
+}
+
+// NotificationAttempt satu kali percobaan kirim
+type NotificationAttempt struct {
+	ID             uint      `gorm:"primaryKey" json:"id"`
+	NotificationID uint      `gorm:"index" json:"notification_id"`
+	Attempt        int       `json:"attempt"`
+	Success        bool      `json:"success"`
+	Error          string    `json:"error"`
+	DurationMs     int64     `json:"duration_ms"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// Request DTOs
+type NotificationListQuery struct {
+	OrderID string              `query:"order_id" validate:"max=50"`
+	Event   NotificationEvent   `query:"event" validate:"omitempty,oneof=order.created order.payment_verified order.rejected order.shipped"`
+	Channel NotificationChannel `query:"channel" validate:"omitempty,oneof=whatsapp email"`
+	Status  NotificationStatus  `query:"status" validate:"omitempty,oneof=pending sent failed"`
+}
+
+// Response DTOs
+type NotificationAttemptResponse struct {
+	Attempt    int    `json:"attempt"`
+	Success    bool   `json:"success"`
+	Error      string `json:"error,omitempty"`
+	DurationMs int64  `json:"duration_ms"`
+	CreatedAt  string `json:"created_at"`
+}
+
+type NotificationResponse struct {
+	ID            uint                          `json:"id"`
+	OrderID       string                        `json:"order_id"`
+	Event         NotificationEvent             `json:"event"`
+	Channel       NotificationChannel           `json:"channel"`
+	Provider      string                        `json:"provider"`
+	Recipient     string                        `json:"recipient"`
+	Subject       string                        `json:"subject,omitempty"`
+	Body          string                        `json:"body"`
+	Status        NotificationStatus            `json:"status"`
+	Attempts      int                           `json:"attempts"`
+	NextAttemptAt *string                       `json:"next_attempt_at"`
+	LastError     string                        `json:"last_error,omitempty"`
+	SentAt        *string                       `json:"sent_at"`
+	AttemptLogs   []NotificationAttemptResponse `json:"attempt_logs,omitempty"`
+	CreatedAt     string                        `json:"created_at"`
+}
+
+type RetryNotificationResponse struct {
+	Message      string               `json:"message"`
+	Notification NotificationResponse `json:"notification"`
+}
@@ -8,32 +8,88 @@ const (
 	OrderStatusPending  OrderStatus = "pending"
 	OrderStatusSuccess  OrderStatus = "success"
 	OrderStatusRejected OrderStatus = "rejected"
+	// Dibatalkan otomatis oleh sistem, misalnya pembayaran tidak dikonfirmasi
+	OrderStatusCancelled OrderStatus = "cancelled"
+)
+
+type FulfilmentMethod string
+
+const (
+	FulfilmentDelivery FulfilmentMethod = "delivery"
+	FulfilmentPickup   FulfilmentMethod = "pickup"
 )
 
 type Order struct {
-	ID             string      `gorm:"primaryKey" json:"id"`
-	CustomerName   string      `json:"customer_name"`
-	Whatsapp       string      `json:"whatsapp"`
-	MapAddress     string      `json:"map_address"`
-	Latitude       float64     `json:"latitude"`
-	Longitude      float64     `json:"longitude"`
-	AddressNote    string      `json:"address_note"`
-	TotalPrice     float64     `json:"total_price"`
-	ProofOfPayment string      `json:"proof_of_payment"`
-	Status         OrderStatus `gorm:"default:pending" json:"status"`
-	OrderItems     []OrderItem `gorm:"foreignKey:OrderID;constraint:OnDelete:CASCADE;" json:"order_items"`
-	CreatedAt      time.Time   `json:"created_at"`
+	ID               string           `gorm:"primaryKey" json:"id"`
+	OrderNumber      string           `gorm:"uniqueIndex:idx_orders_order_number,where:order_number <> ''" json:"order_number"`
+	CustomerID       *uint            `gorm:"index" json:"customer_id"`
+	Customer         *Customer        `gorm:"constraint:OnDelete:SET NULL;" json:"-"`
+	CustomerName     string           `json:"customer_name"`
+	Whatsapp         string           `gorm:"index" json:"whatsapp"`
+	Email            string           `json:"email"`
+	Language         string           `gorm:"size:5" json:"language"`
+	MapAddress       string           `json:"map_address"`
+	Latitude         float64          `json:"latitude"`
+	Longitude        float64          `json:"longitude"`
+	AddressNote      string           `json:"address_note"`
+	FulfilmentMethod FulfilmentMethod `gorm:"not null;default:delivery" json:"fulfilment_method"`
+	PickupCode       string           `gorm:"index" json:"pickup_code"`
+	CollectedAt      *time.Time       `json:"collected_at"`
+	Subtotal         float64          `json:"subtotal"`
+	DiscountTotal    float64          `json:"discount_total"`
+	DistanceKm       float64          `json:"distance_km"`
+	DeliveryFee      float64          `json:"delivery_fee"`
+	DeliveryZoneID   *uint            `json:"delivery_zone_id"`
+	DeliveryZone     string           `json:"delivery_zone"`
+	DeliveryDate     *time.Time       `gorm:"type:date" json:"delivery_date"`
+	DestinationCity  string           `json:"destination_city"`
+	Courier          string           `json:"courier"`
+	CourierService   string           `json:"courier_service"`
+	ShippingWeight   int              `json:"shipping_weight"`
+	AirwayBill       string           `gorm:"index" json:"airway_bill"`
+	TrackingStatus   string           `json:"tracking_status"`
+	TotalPrice       float64          `gorm:"index" json:"total_price"`
+	VoucherCode      string           `json:"voucher_code"`
+	PaymentMethod    PaymentMethod    `gorm:"not null;default:manual_transfer" json:"payment_method"`
+	ProofOfPayment   string           `json:"proof_of_payment"`
+	PaidAt           *time.Time       `json:"paid_at"`
+	Status           OrderStatus      `gorm:"default:pending;index:idx_orders_status_created_at,priority:1" json:"status"`
+	CancelReason     string           `json:"cancel_reason"`
+	CancelledAt      *time.Time       `json:"cancelled_at"`
+	OrderItems       []OrderItem      `gorm:"foreignKey:OrderID;constraint:OnDelete:CASCADE;" json:"order_items"`
+	Discounts        []OrderDiscount  `gorm:"foreignKey:OrderID;constraint:OnDelete:CASCADE;" json:"discounts"`
+	Payments         []Payment        `gorm:"foreignKey:OrderID;constraint:OnDelete:CASCADE;" json:"payments"`
+	CreatedAt        time.Time        `gorm:"index;index:idx_orders_status_created_at,priority:2" json:"created_at"`
 }
 
 type OrderItem struct {
 	ID              uint    `gorm:"primaryKey" json:"id"`
-	OrderID         string  `json:"order_id"`
-	ProductID       uint    `json:"product_id"`
+	OrderID         string  `gorm:"index" json:"order_id"`
+	ProductID       uint    `gorm:"index" json:"product_id"`
 	Product         Product `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"product"`
 	Quantity        int     `json:"quantity"`
 	PriceAtPurchase float64 `json:"price_at_purchase"`
 }
 
+type OrderDiscountType string
+
+const (
+	OrderDiscountTypeVoucher   OrderDiscountType = "voucher"
+	OrderDiscountTypePromotion OrderDiscountType = "promotion"
+)
+
+// OrderDiscount satu baris potongan harga pada order
+type OrderDiscount struct {
+	ID          uint              `gorm:"primaryKey" json:"id"`
+	OrderID     string            `gorm:"index" json:"order_id"`
+	Type        OrderDiscountType `json:"type"`
+	VoucherID   *uint             `json:"voucher_id"`
+	PromotionID *uint             `gorm:"index" json:"promotion_id"`
+	Code        string            `json:"code"`
+	Description string            `json:"description"`
+	Amount      float64           `json:"amount"`
+}
+
 // Request DTOs
 type OrderItemRequest struct {
 	ProductID uint `json:"product_id" validate:"required,gt=0"`
@@ -41,13 +97,42 @@ type OrderItemRequest struct {
 }
 
 type CreateOrderRequest struct {
-	CustomerName string             `json:"customer_name" form:"customer_name" validate:"required,min=2,max=100"`
-	Whatsapp     string             `json:"whatsapp" form:"whatsapp" validate:"required,min=10,max=15"`
-	MapAddress   string             `json:"map_address" form:"map_address" validate:"max=500"`
-	Latitude     float64            `json:"latitude" form:"latitude" validate:"gte=-90,lte=90"`
-	Longitude    float64            `json:"longitude" form:"longitude" validate:"gte=-180,lte=180"`
-	AddressNote  string             `json:"address_note" form:"address_note" validate:"max=500"`
-	Items        []OrderItemRequest `json:"items" validate:"required,min=1,max=50,dive"`
+	CustomerName string `json:"customer_name" form:"customer_name" validate:"required,min=2,max=100"`
+	Whatsapp     string `json:"whatsapp" form:"whatsapp" validate:"required,whatsapp"`
+	// Opsional, untuk notifikasi email
+	Email string `json:"email" form:"email" validate:"omitempty,email,max=100"`
+	// Bahasa notifikasi, kosong = bahasa default toko
+	Language string `json:"language" form:"language" validate:"omitempty,oneof=id en"`
+	// Alamat tidak wajib untuk ambil di toko
+	FulfilmentMethod FulfilmentMethod `json:"fulfilment_method" form:"fulfilment_method" validate:"omitempty,oneof=delivery pickup"`
+	MapAddress       string           `json:"map_address" form:"map_address" validate:"required_unless=FulfilmentMethod pickup,max=500"`
+	Latitude         float64          `json:"latitude" form:"latitude" validate:"gte=-90,lte=90"`
+	Longitude        float64          `json:"longitude" form:"longitude" validate:"gte=-180,lte=180"`
+	AddressNote      string           `json:"address_note" form:"address_note" validate:"max=500"`
+	VoucherCode      string           `json:"voucher_code" form:"voucher_code" validate:"max=50"`
+	// Kosong = transfer manual, bukti transfer wajib diupload
+	PaymentMethod PaymentMethod `json:"payment_method" form:"payment_method" validate:"omitempty,oneof=manual_transfer gateway"`
+	// Diisi hanya untuk pengiriman antar kota via kurir
+	DestinationCity string             `json:"destination_city" form:"destination_city" validate:"max=100"`
+	Courier         string             `json:"courier" form:"courier" validate:"max=20"`
+	CourierService  string             `json:"courier_service" form:"courier_service" validate:"required_with=Courier,max=20"`
+	Items           []OrderItemRequest `json:"items" validate:"required,min=1,max=50,dive"`
+}
+
+type QuoteOrderRequest struct {
+	Whatsapp         string             `json:"whatsapp" validate:"omitempty,whatsapp"`
+	FulfilmentMethod FulfilmentMethod   `json:"fulfilment_method" validate:"omitempty,oneof=delivery pickup"`
+	Latitude         float64            `json:"latitude" validate:"gte=-90,lte=90"`
+	Longitude        float64            `json:"longitude" validate:"gte=-180,lte=180"`
+	VoucherCode      string             `json:"voucher_code" validate:"max=50"`
+	DestinationCity  string             `json:"destination_city" validate:"max=100"`
+	Courier          string             `json:"courier" validate:"max=20"`
+	CourierService   string             `json:"courier_service" validate:"required_with=Courier,max=20"`
+	Items            []OrderItemRequest `json:"items" validate:"required,min=1,max=50,dive"`
+}
+
+type CollectOrderRequest struct {
+	PickupCode string `json:"pickup_code" validate:"required,len=6,alphanum"`
 }
 
 type UpdateOrderStatusRequest struct {
@@ -63,19 +148,70 @@ type OrderItemResponse struct {
 	PriceAtPurchase float64         `json:"price_at_purchase"`
 }
 
+type OrderDiscountResponse struct {
+	Type        OrderDiscountType `json:"type"`
+	Code        string            `json:"code"`
+	Description string            `json:"description"`
+	Amount      float64           `json:"amount"`
+}
+
 type OrderResponse struct {
-	ID             string              `json:"id"`
-	CustomerName   string              `json:"customer_name"`
-	Whatsapp       string              `json:"whatsapp"`
-	MapAddress     string              `json:"map_address"`
-	Latitude       float64             `json:"latitude"`
-	Longitude      float64             `json:"longitude"`
-	AddressNote    string              `json:"address_note"`
-	TotalPrice     float64             `json:"total_price"`
-	ProofOfPayment string              `json:"proof_of_payment"`
-	Status         OrderStatus         `json:"status"`
-	OrderItems     []OrderItemResponse `json:"order_items"`
-	CreatedAt      string              `json:"created_at"`
+	ID               string                  `json:"id"`
+	OrderNumber      string                  `json:"order_number"`
+	CustomerID       *uint                   `json:"customer_id"`
+	CustomerName     string                  `json:"customer_name"`
+	Whatsapp         string                  `json:"whatsapp"`
+	Email            string                  `json:"email"`
+	Language         string                  `json:"language"`
+	MapAddress       string                  `json:"map_address"`
+	Latitude         float64                 `json:"latitude"`
+	Longitude        float64                 `json:"longitude"`
+	AddressNote      string                  `json:"address_note"`
+	FulfilmentMethod FulfilmentMethod        `json:"fulfilment_method"`
+	PickupCode       string                  `json:"pickup_code,omitempty"`
+	CollectedAt      *string                 `json:"collected_at"`
+	Subtotal         float64                 `json:"subtotal"`
+	DiscountTotal    float64                 `json:"discount_total"`
+	DistanceKm       float64                 `json:"distance_km"`
+	DeliveryFee      float64                 `json:"delivery_fee"`
+	DeliveryZone     string                  `json:"delivery_zone"`
+	DeliveryDate     *string                 `json:"delivery_date"`
+	DestinationCity  string                  `json:"destination_city"`
+	Courier          string                  `json:"courier"`
+	CourierService   string                  `json:"courier_service"`
+	ShippingWeight   int                     `json:"shipping_weight"`
+	AirwayBill       string                  `json:"airway_bill"`
+	TrackingStatus   string                  `json:"tracking_status"`
+	TotalPrice       float64                 `json:"total_price"`
+	VoucherCode      string                  `json:"voucher_code"`
+	PaymentMethod    PaymentMethod           `json:"payment_method"`
+	ProofOfPayment   string                  `json:"proof_of_payment"`
+	PaidAt           *string                 `json:"paid_at"`
+	Payments         []PaymentResponse       `json:"payments,omitempty"`
+	Status           OrderStatus             `json:"status"`
+	CancelReason     string                  `json:"cancel_reason,omitempty"`
+	CancelledAt      *string                 `json:"cancelled_at"`
+	OrderItems       []OrderItemResponse     `json:"order_items"`
+	Discounts        []OrderDiscountResponse `json:"discounts"`
+	CreatedAt        string                  `json:"created_at"`
+	// Hanya diisi di tampilan admin
+	WhatsappLinks []WhatsappLink `json:"whatsapp_links,omitempty"`
+}
+
+type WhatsappLinkPurpose string
+
+const (
+	WhatsappLinkChat            WhatsappLinkPurpose = "chat"
+	WhatsappLinkPaymentReminder WhatsappLinkPurpose = "payment_reminder"
+	WhatsappLinkConfirmation    WhatsappLinkPurpose = "confirmation"
+	WhatsappLinkShippingInfo    WhatsappLinkPurpose = "shipping_info"
+)
+
+// WhatsappLink link wa.me ke nomor customer dengan pesan yang sudah terisi
+type WhatsappLink struct {
+	Purpose WhatsappLinkPurpose `json:"purpose"`
+	Message string              `json:"message,omitempty"`
+	URL     string              `json:"url"`
 }
 
 type CreateOrderResponse struct {
@@ -91,6 +227,46 @@ type GetOrdersResponse struct {
 	Orders []*OrderResponse `json:"orders"`
 }
 
+type QuoteLineResponse struct {
+	ProductID uint    `json:"product_id"`
+	Name      string  `json:"name"`
+	UnitPrice float64 `json:"unit_price"`
+	Quantity  int     `json:"quantity"`
+	LineTotal float64 `json:"line_total"`
+	Available int     `json:"available"`
+}
+
+// QuoteItemProblem masalah per item yang akan membuat order gagal
+type QuoteItemProblem struct {
+	ProductID uint   `json:"product_id"`
+	Requested int    `json:"requested"`
+	Available int    `json:"available"`
+	Message   string `json:"message"`
+}
+
+type QuoteOrderResponse struct {
+	Items         []QuoteLineResponse     `json:"items"`
+	Subtotal      float64                 `json:"subtotal"`
+	DistanceKm    float64                 `json:"distance_km"`
+	ShippingFee   float64                 `json:"shipping_fee"`
+	DeliveryZone  string                  `json:"delivery_zone,omitempty"`
+	DeliveryDate  *string                 `json:"delivery_date,omitempty"`
+	CourierRates  []CourierRate           `json:"courier_rates,omitempty"`
+	DiscountTotal float64                 `json:"discount_total"`
+	Discounts     []OrderDiscountResponse `json:"discounts"`
+	TotalPrice    float64                 `json:"total_price"`
+	VoucherCode   string                  `json:"voucher_code"`
+	VoucherError  string                  `json:"voucher_error,omitempty"`
+	DeliveryError string                  `json:"delivery_error,omitempty"`
+	Problems      []QuoteItemProblem      `json:"problems"`
+	Orderable     bool                    `json:"orderable"`
+}
+
+type CollectOrderResponse struct {
+	Message string        `json:"message"`
+	Order   OrderResponse `json:"order"`
+}
+
 type UpdateOrderStatusResponse struct {
 	Message string        `json:"message"`
 	Order   OrderResponse `json:"order"`
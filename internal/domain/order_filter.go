@@ -0,0 +1,45 @@
+package domain
+
+import "time"
+
+// OrderListQuery filter daftar order admin dari query string
+type OrderListQuery struct {
+	Status       OrderStatus `query:"status" validate:"omitempty,oneof=pending success rejected cancelled"`
+	From         string      `query:"from" validate:"omitempty,datetime=2006-01-02"`
+	To           string      `query:"to" validate:"omitempty,datetime=2006-01-02"`
+	CustomerName string      `query:"customer_name" validate:"max=100"`
+	Whatsapp     string      `query:"whatsapp" validate:"max=20"`
+	OrderID      string      `query:"order_id" validate:"max=50"`
+	OrderNumber  string      `query:"order_number" validate:"max=50"`
+	MinTotal     float64     `query:"min_total" validate:"gte=0"`
+	MaxTotal     float64     `query:"max_total" validate:"gte=0"`
+	ProductID    uint        `query:"product_id"`
+	CustomerID   uint        `query:"customer_id"`
+	SortBy       string      `query:"sort_by" validate:"omitempty,oneof=created_at total_price customer_name"`
+	SortOrder    string      `query:"sort_order" validate:"omitempty,oneof=asc desc"`
+}
+
+// OrderFilter filter yang sudah diolah untuk repository, field kosong/nol diabaikan
+type OrderFilter struct {
+	Status        OrderStatus
+	CreatedFrom   *time.Time
+	CreatedBefore *time.Time
+	CustomerName  string
+	Whatsapp      string
+	IDPrefix      string
+	NumberPrefix  string
+	MinTotal      float64
+	MaxTotal      float64
+	ProductID     uint
+	CustomerID    uint
+	SortBy        string
+	SortOrder     string
+}
+
+// ExportOrdersQuery filter sama dengan daftar order, ditambah format file dan mode baris
+type ExportOrdersQuery struct {
+	OrderListQuery
+	Format string `query:"format" validate:"omitempty,oneof=csv xlsx"`
+	// Rows order = satu baris per order, item = satu baris per order item
+	Rows string `query:"rows" validate:"omitempty,oneof=order item"`
+}
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// OrderSequence counter nomor order per periode, Period = pola yang sudah diisi tanggal
+type OrderSequence struct {
+	Period     string `gorm:"primaryKey;size:100"`
+	LastNumber int    `gorm:"not null"`
+	UpdatedAt  time.Time
+}
+
+const OrderNumberSeqToken = "{SEQ}"
+
+// OrderNumberFormat pola nomor order, contoh BTK-{YYYY}{MM}-{SEQ} -> BTK-202610-00042.
+// Token tanggal ({YYYY}, {YY}, {MM}, {DD}) menentukan periode reset counter.
+type OrderNumberFormat struct {
+	Pattern  string
+	Padding  int
+	Location *time.Location
+}
+
+// Period pola dengan token tanggal terisi, dipakai sebagai key counter
+func (f OrderNumberFormat) Period(t time.Time) string {
+	t = t.In(f.Location)
+	return strings.NewReplacer(
+		"{YYYY}", t.Format("2006"),
+		"{YY}", t.Format("06"),
+		"{MM}", t.Format("01"),
+		"{DD}", t.Format("02"),
+	).Replace(f.Pattern)
+}
+
+// Format nomor order dari periode dan urutan
+func (f OrderNumberFormat) Format(period string, seq int) string {
+	return strings.Replace(period, OrderNumberSeqToken, fmt.Sprintf("%0*d", f.Padding, seq), 1)
+}
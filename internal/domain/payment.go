@@ -0,0 +1,62 @@
+package domain
+
+import "time"
+
+type PaymentMethod string
+
+const (
+	// Transfer manual dengan upload bukti, diverifikasi admin
+	PaymentMethodManualTransfer PaymentMethod = "manual_transfer"
+	// Dibayar lewat payment gateway, order otomatis success setelah callback
+	PaymentMethodGateway PaymentMethod = "gateway"
+)
+
+type PaymentStatus string
+
+const (
+	PaymentStatusPending PaymentStatus = "pending"
+	PaymentStatusPaid    PaymentStatus = "paid"
+	PaymentStatusFailed  PaymentStatus = "failed"
+	PaymentStatusExpired PaymentStatus = "expired"
+)
+
+// Payment satu tagihan di payment gateway untuk order, Reference dikirim ke provider sebagai ID transaksi
+type Payment struct {
+	ID         uint          `gorm:"primaryKey" json:"id"`
+	OrderID    string        `gorm:"index" json:"order_id"`
+	Provider   string        `gorm:"not null" json:"provider"`
+	Reference  string        `gorm:"uniqueIndex;not null" json:"reference"`
+	Amount     float64       `json:"amount"`
+	Status     PaymentStatus `gorm:"default:pending;index" json:"status"`
+	PaymentURL string        `json:"payment_url"`
+	ExpiresAt  *time.Time    `json:"expires_at"`
+	PaidAt     *time.Time    `json:"paid_at"`
+	CreatedAt  time.Time     `json:"created_at"`
+	UpdatedAt  time.Time     `json:"updated_at"`
+}
+
+// Request DTOs
+type SimulatePaymentRequest struct {
+	Status PaymentStatus `json:"status" validate:"required,oneof=paid failed expired"`
+}
+
+// Response DTOs
+type PaymentResponse struct {
+	Provider   string        `json:"provider"`
+	Reference  string        `json:"reference"`
+	Amount     float64       `json:"amount"`
+	Status     PaymentStatus `json:"status"`
+	PaymentURL string        `json:"payment_url,omitempty"`
+	ExpiresAt  *string       `json:"expires_at"`
+	PaidAt     *string       `json:"paid_at"`
+	CreatedAt  string        `json:"created_at"`
+}
+
+type CreatePaymentResponse struct {
+	Message string          `json:"message"`
+	Payment PaymentResponse `json:"payment"`
+}
+
+type PaymentCallbackResponse struct {
+	Message string `json:"message"`
+}
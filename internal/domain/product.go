@@ -3,42 +3,67 @@ package domain
 import "time"
 
 type Product struct {
-	ID          uint      `gorm:"primaryKey" json:"id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	Price       float64   `json:"price"`
-	Stock       int       `json:"stock"`
-	CategoryID  uint      `json:"category_id"`
-	Category    Category  `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"category"`
-	ImageURL    string    `json:"image_url"`
-	CreatedAt   time.Time `json:"created_at"`
+	ID          uint    `gorm:"primaryKey" json:"id"`
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Price       float64 `json:"price"`
+	Stock       int     `json:"stock"`
+	// Reserved stok yang ditahan order pending, stok tersedia = Stock - Reserved
+	Reserved int `gorm:"not null;default:0" json:"reserved"`
+	Weight   int `gorm:"not null;default:0" json:"weight"`
+	// LowStockThreshold 0 berarti pakai threshold default toko
+	LowStockThreshold int       `gorm:"not null;default:0" json:"low_stock_threshold"`
+	CategoryID        uint      `json:"category_id"`
+	Category          Category  `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"category"`
+	ImageURL          string    `json:"image_url"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+func (p *Product) AvailableStock() int {
+	return p.Stock - p.Reserved
+}
+
+// LowStockLimit threshold product, atau defaultThreshold jika tidak diatur
+func (p *Product) LowStockLimit(defaultThreshold int) int {
+	if p.LowStockThreshold > 0 {
+		return p.LowStockThreshold
+	}
+	return defaultThreshold
 }
 
 type ProductResponse struct {
-	ID          uint             `json:"id"`
-	Name        string           `json:"name"`
-	Description string           `json:"description"`
-	Price       float64          `json:"price"`
-	Stock       int              `json:"stock"`
-	Category    CategoryResponse `json:"category"`
-	ImageURL    string           `json:"image_url"`
-	CreatedAt   string           `json:"created_at"`
+	ID                uint             `json:"id"`
+	Name              string           `json:"name"`
+	Description       string           `json:"description"`
+	Price             float64          `json:"price"`
+	Stock             int              `json:"stock"`
+	Reserved          int              `json:"reserved"`
+	Available         int              `json:"available"`
+	Weight            int              `json:"weight"`
+	LowStockThreshold int              `json:"low_stock_threshold"`
+	Category          CategoryResponse `json:"category"`
+	ImageURL          string           `json:"image_url"`
+	CreatedAt         string           `json:"created_at"`
 }
 
 type CreateProductRequest struct {
-	Name        string  `json:"name" form:"name" validate:"required,min=2,max=200"`
-	Description string  `json:"description" form:"description" validate:"required,min=10,max=2000"`
-	Price       float64 `json:"price" form:"price" validate:"required,gt=0,lte=999999999"`
-	Stock       int     `json:"stock" form:"stock" validate:"required,gte=0,lte=99999"`
-	CategoryID  uint    `json:"category_id" form:"category_id" validate:"required,gt=0"`
+	Name              string  `json:"name" form:"name" validate:"required,min=2,max=200"`
+	Description       string  `json:"description" form:"description" validate:"required,min=10,max=2000"`
+	Price             float64 `json:"price" form:"price" validate:"required,gt=0,lte=999999999"`
+	Stock             int     `json:"stock" form:"stock" validate:"required,gte=0,lte=99999"`
+	Weight            int     `json:"weight" form:"weight" validate:"gte=0,lte=100000"`
+	LowStockThreshold int     `json:"low_stock_threshold" form:"low_stock_threshold" validate:"gte=0,lte=99999"`
+	CategoryID        uint    `json:"category_id" form:"category_id" validate:"required,gt=0"`
 }
 
+// UpdateProductRequest tanpa stok, perubahan stok lewat stock adjustment/restock
 type UpdateProductRequest struct {
-	Name        string  `json:"name" form:"name" validate:"required,min=2,max=200"`
-	Description string  `json:"description" form:"description" validate:"required,min=10,max=2000"`
-	Price       float64 `json:"price" form:"price" validate:"required,gt=0,lte=999999999"`
-	Stock       int     `json:"stock" form:"stock" validate:"required,gte=0,lte=99999"`
-	CategoryID  uint    `json:"category_id" form:"category_id" validate:"required,gt=0"`
+	Name              string  `json:"name" form:"name" validate:"required,min=2,max=200"`
+	Description       string  `json:"description" form:"description" validate:"required,min=10,max=2000"`
+	Price             float64 `json:"price" form:"price" validate:"required,gt=0,lte=999999999"`
+	Weight            int     `json:"weight" form:"weight" validate:"gte=0,lte=100000"`
+	LowStockThreshold int     `json:"low_stock_threshold" form:"low_stock_threshold" validate:"gte=0,lte=99999"`
+	CategoryID        uint    `json:"category_id" form:"category_id" validate:"required,gt=0"`
 }
 
 type CreateProductResponse struct {
@@ -54,3 +79,16 @@ type UpdateProductResponse struct {
 type DeleteProductResponse struct {
 	Message string `json:"message"`
 }
+
+type LowStockStatus string
+
+const (
+	LowStockStatusLow        LowStockStatus = "low"
+	LowStockStatusOutOfStock LowStockStatus = "out_of_stock"
+)
+
+type LowStockProductResponse struct {
+	Product   ProductResponse `json:"product"`
+	Threshold int             `json:"threshold"`
+	Status    LowStockStatus  `json:"status"`
+}
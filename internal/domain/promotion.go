@@ -0,0 +1,122 @@
+package domain
+
+import "time"
+
+type PromotionType string
+
+const (
+	PromotionTypePercentage  PromotionType = "percentage"
+	PromotionTypeFixed       PromotionType = "fixed"
+	PromotionTypeBuyXGetY    PromotionType = "buy_x_get_y"
+	PromotionTypeFreeProduct PromotionType = "free_product"
+	PromotionTypeBundle      PromotionType = "bundle"
+)
+
+// Promotion aturan diskon otomatis yang dievaluasi saat checkout tanpa kode
+type Promotion struct {
+	ID              uint          `gorm:"primaryKey" json:"id"`
+	Name            string        `gorm:"not null" json:"name"`
+	Description     string        `json:"description"`
+	Type            PromotionType `gorm:"not null" json:"type"`
+	Priority        int           `gorm:"not null;default:0" json:"priority"`
+	Stackable       bool          `gorm:"not null" json:"stackable"`
+	IsActive        bool          `gorm:"not null" json:"is_active"`
+	StartsAt        *time.Time    `json:"starts_at"`
+	EndsAt          *time.Time    `json:"ends_at"`
+	MinQuantity     int           `json:"min_quantity"`
+	MinSubtotal     float64       `json:"min_subtotal"`
+	DiscountValue   float64       `json:"discount_value"`
+	MaxDiscount     float64       `json:"max_discount"`
+	BuyQuantity     int           `json:"buy_quantity"`
+	GetQuantity     int           `json:"get_quantity"`
+	RewardProductID *uint         `json:"reward_product_id"`
+	RewardQuantity  int           `json:"reward_quantity"`
+	BundlePrice     float64       `json:"bundle_price"`
+	Categories      []Category    `gorm:"many2many:promotion_categories;" json:"categories"`
+	Products        []Product     `gorm:"many2many:promotion_products;" json:"products"`
+	CreatedAt       time.Time     `json:"created_at"`
+}
+
+// Request DTOs
+type CreatePromotionRequest struct {
+	Name            string        `json:"name" validate:"required,min=2,max=100"`
+	Description     string        `json:"description" validate:"max=255"`
+	Type            PromotionType `json:"type" validate:"required,oneof=percentage fixed buy_x_get_y free_product bundle"`
+	Priority        int           `json:"priority" validate:"gte=0,lte=1000"`
+	Stackable       bool          `json:"stackable"`
+	IsActive        *bool         `json:"is_active"`
+	StartsAt        *time.Time    `json:"starts_at"`
+	EndsAt          *time.Time    `json:"ends_at"`
+	MinQuantity     int           `json:"min_quantity" validate:"gte=0,lte=1000"`
+	MinSubtotal     float64       `json:"min_subtotal" validate:"gte=0,lte=999999999"`
+	DiscountValue   float64       `json:"discount_value" validate:"gte=0,lte=999999999"`
+	MaxDiscount     float64       `json:"max_discount" validate:"gte=0,lte=999999999"`
+	BuyQuantity     int           `json:"buy_quantity" validate:"gte=0,lte=100"`
+	GetQuantity     int           `json:"get_quantity" validate:"gte=0,lte=100"`
+	RewardProductID *uint         `json:"reward_product_id" validate:"omitempty,gt=0"`
+	RewardQuantity  int           `json:"reward_quantity" validate:"gte=0,lte=100"`
+	BundlePrice     float64       `json:"bundle_price" validate:"gte=0,lte=999999999"`
+	CategoryIDs     []uint        `json:"category_ids" validate:"max=100,dive,gt=0"`
+	ProductIDs      []uint        `json:"product_ids" validate:"max=100,dive,gt=0"`
+}
+
+type UpdatePromotionRequest struct {
+	Name            string        `json:"name" validate:"required,min=2,max=100"`
+	Description     string        `json:"description" validate:"max=255"`
+	Type            PromotionType `json:"type" validate:"required,oneof=percentage fixed buy_x_get_y free_product bundle"`
+	Priority        int           `json:"priority" validate:"gte=0,lte=1000"`
+	Stackable       bool          `json:"stackable"`
+	IsActive        *bool         `json:"is_active"`
+	StartsAt        *time.Time    `json:"starts_at"`
+	EndsAt          *time.Time    `json:"ends_at"`
+	MinQuantity     int           `json:"min_quantity" validate:"gte=0,lte=1000"`
+	MinSubtotal     float64       `json:"min_subtotal" validate:"gte=0,lte=999999999"`
+	DiscountValue   float64       `json:"discount_value" validate:"gte=0,lte=999999999"`
+	MaxDiscount     float64       `json:"max_discount" validate:"gte=0,lte=999999999"`
+	BuyQuantity     int           `json:"buy_quantity" validate:"gte=0,lte=100"`
+	GetQuantity     int           `json:"get_quantity" validate:"gte=0,lte=100"`
+	RewardProductID *uint         `json:"reward_product_id" validate:"omitempty,gt=0"`
+	RewardQuantity  int           `json:"reward_quantity" validate:"gte=0,lte=100"`
+	BundlePrice     float64       `json:"bundle_price" validate:"gte=0,lte=999999999"`
+	CategoryIDs     []uint        `json:"category_ids" validate:"max=100,dive,gt=0"`
+	ProductIDs      []uint        `json:"product_ids" validate:"max=100,dive,gt=0"`
+}
+
+// Response DTOs
+type PromotionResponse struct {
+	ID              uint          `json:"id"`
+	Name            string        `json:"name"`
+	Description     string        `json:"description"`
+	Type            PromotionType `json:"type"`
+	Priority        int           `json:"priority"`
+	Stackable       bool          `json:"stackable"`
+	IsActive        bool          `json:"is_active"`
+	StartsAt        *string       `json:"starts_at"`
+	EndsAt          *string       `json:"ends_at"`
+	MinQuantity     int           `json:"min_quantity"`
+	MinSubtotal     float64       `json:"min_subtotal"`
+	DiscountValue   float64       `json:"discount_value"`
+	MaxDiscount     float64       `json:"max_discount"`
+	BuyQuantity     int           `json:"buy_quantity"`
+	GetQuantity     int           `json:"get_quantity"`
+	RewardProductID *uint         `json:"reward_product_id"`
+	RewardQuantity  int           `json:"reward_quantity"`
+	BundlePrice     float64       `json:"bundle_price"`
+	CategoryIDs     []uint        `json:"category_ids"`
+	ProductIDs      []uint        `json:"product_ids"`
+	CreatedAt       string        `json:"created_at"`
+}
+
+type CreatePromotionResponse struct {
+	Message   string            `json:"message"`
+	Promotion PromotionResponse `json:"promotion"`
+}
+
+type UpdatePromotionResponse struct {
+	Message   string            `json:"message"`
+	Promotion PromotionResponse `json:"promotion"`
+}
+
+type DeletePromotionResponse struct {
+	Message string `json:"message"`
+}
@@ -0,0 +1,68 @@
+package domain
+
+// Request DTOs
+type ReportQuery struct {
+	// Tanggal dalam timezone toko, from dan to inklusif
+	From    string `query:"from" validate:"omitempty,datetime=2006-01-02"`
+	To      string `query:"to" validate:"omitempty,datetime=2006-01-02"`
+	GroupBy string `query:"group_by" validate:"omitempty,oneof=day week month"`
+	SortBy  string `query:"sort_by" validate:"omitempty,oneof=units revenue"`
+	Limit   int    `query:"limit" validate:"omitempty,gte=1,lte=100"`
+}
+
+// Response DTOs
+type SalesPeriodRow struct {
+	Period     string  `json:"period"`
+	OrderCount int     `json:"order_count"`
+	Revenue    float64 `json:"revenue"`
+}
+
+type SalesReportResponse struct {
+	From              string           `json:"from"`
+	To                string           `json:"to"`
+	GroupBy           string           `json:"group_by"`
+	OrderCount        int              `json:"order_count"`
+	Revenue           float64          `json:"revenue"`
+	AverageOrderValue float64          `json:"average_order_value"`
+	Series            []SalesPeriodRow `json:"series"`
+}
+
+type TopProductRow struct {
+	ProductID uint    `json:"product_id"`
+	Name      string  `json:"name"`
+	Units     int     `json:"units"`
+	Revenue   float64 `json:"revenue"`
+}
+
+type TopCategoryRow struct {
+	CategoryID uint    `json:"category_id"`
+	Name       string  `json:"name"`
+	Units      int     `json:"units"`
+	Revenue    float64 `json:"revenue"`
+}
+
+type StatusBreakdownRow struct {
+	Status     OrderStatus `json:"status"`
+	OrderCount int         `json:"order_count"`
+	Total      float64     `json:"total"`
+}
+
+type TopProductsResponse struct {
+	From     string          `json:"from"`
+	To       string          `json:"to"`
+	SortBy   string          `json:"sort_by"`
+	Products []TopProductRow `json:"products"`
+}
+
+type TopCategoriesResponse struct {
+	From       string           `json:"from"`
+	To         string           `json:"to"`
+	SortBy     string           `json:"sort_by"`
+	Categories []TopCategoryRow `json:"categories"`
+}
+
+type StatusBreakdownResponse struct {
+	From     string               `json:"from"`
+	To       string               `json:"to"`
+	Statuses []StatusBreakdownRow `json:"statuses"`
+}
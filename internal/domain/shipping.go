@@ -0,0 +1,44 @@
+package domain
+
+import "time"
+
+// CourierRate satu layanan kurir untuk rute dan berat tertentu
+type CourierRate struct {
+	Courier     string  `json:"courier"`
+	Service     string  `json:"service"`
+	Description string  `json:"description"`
+	Fee         float64 `json:"fee"`
+	EtaDays     string  `json:"eta_days"`
+}
+
+type TrackingEvent struct {
+	Time        time.Time `json:"time"`
+	Status      string    `json:"status"`
+	Description string    `json:"description"`
+	Location    string    `json:"location"`
+}
+
+type TrackingInfo struct {
+	Courier    string          `json:"courier"`
+	AirwayBill string          `json:"airway_bill"`
+	Status     string          `json:"status"`
+	Delivered  bool            `json:"delivered"`
+	Events     []TrackingEvent `json:"events"`
+}
+
+// Request DTOs
+type AttachAirwayBillRequest struct {
+	AirwayBill string `json:"airway_bill" validate:"required,min=5,max=50,alphanum"`
+}
+
+// Response DTOs
+type AttachAirwayBillResponse struct {
+	Message  string        `json:"message"`
+	Order    OrderResponse `json:"order"`
+	Tracking *TrackingInfo `json:"tracking"`
+}
+
+type OrderTrackingResponse struct {
+	OrderID  string        `json:"order_id"`
+	Tracking *TrackingInfo `json:"tracking"`
+}
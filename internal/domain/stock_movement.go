@@ -0,0 +1,82 @@
+package domain
+
+import "time"
+
+type StockMovementReason string
+
+const (
+	StockMovementSale         StockMovementReason = "sale"
+	StockMovementRestock      StockMovementReason = "restock"
+	StockMovementAdjustment   StockMovementReason = "adjustment"
+	StockMovementReturn       StockMovementReason = "return"
+	StockMovementCancellation StockMovementReason = "cancellation"
+)
+
+// StockMovement satu baris ledger perubahan stok, stok product = jumlah semua delta
+type StockMovement struct {
+	ID         uint                `gorm:"primaryKey" json:"id"`
+	ProductID  uint                `gorm:"index;not null" json:"product_id"`
+	Delta      int                 `gorm:"not null" json:"delta"`
+	StockAfter int                 `json:"stock_after"`
+	Reason     StockMovementReason `gorm:"index;not null" json:"reason"`
+	OrderID    *string             `gorm:"index" json:"order_id"`
+	UserID     *uint               `json:"user_id"`
+	Note       string              `json:"note"`
+	CreatedAt  time.Time           `gorm:"index" json:"created_at"`
+}
+
+// StockDrift selisih stok product dengan total ledger
+type StockDrift struct {
+	ProductID   uint
+	Name        string
+	Stock       int
+	LedgerStock int
+}
+
+// Request DTOs
+type AdjustStockRequest struct {
+	// Delta positif menambah, negatif mengurangi stok
+	Delta  int                 `json:"delta" validate:"required,gte=-99999,lte=99999"`
+	Reason StockMovementReason `json:"reason" validate:"required,oneof=restock adjustment return"`
+	Note   string              `json:"note" validate:"max=255"`
+}
+
+type RestockItemRequest struct {
+	ProductID uint `json:"product_id" validate:"required,gt=0"`
+	Quantity  int  `json:"quantity" validate:"required,gt=0,lte=99999"`
+}
+
+type BulkRestockRequest struct {
+	Items []RestockItemRequest `json:"items" validate:"required,min=1,max=200,dive"`
+	Note  string               `json:"note" validate:"max=255"`
+}
+
+// Response DTOs
+type StockMovementResponse struct {
+	ID         uint                `json:"id"`
+	ProductID  uint                `json:"product_id"`
+	Delta      int                 `json:"delta"`
+	StockAfter int                 `json:"stock_after"`
+	Reason     StockMovementReason `json:"reason"`
+	OrderID    *string             `json:"order_id"`
+	UserID     *uint               `json:"user_id"`
+	Note       string              `json:"note"`
+	CreatedAt  string              `json:"created_at"`
+}
+
+type StockHistoryResponse struct {
+	Product     ProductResponse          `json:"product"`
+	LedgerStock int                      `json:"ledger_stock"`
+	Movements   []*StockMovementResponse `json:"movements"`
+}
+
+type AdjustStockResponse struct {
+	Message  string                `json:"message"`
+	Product  ProductResponse       `json:"product"`
+	Movement StockMovementResponse `json:"movement"`
+}
+
+type BulkRestockResponse struct {
+	Message   string                   `json:"message"`
+	Movements []*StockMovementResponse `json:"movements"`
+}
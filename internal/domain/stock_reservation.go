@@ -0,0 +1,23 @@
+package domain
+
+import "time"
+
+type StockReservationStatus string
+
+const (
+	StockReservationActive    StockReservationStatus = "active"
+	StockReservationConverted StockReservationStatus = "converted"
+	StockReservationReleased  StockReservationStatus = "released"
+)
+
+// StockReservation stok yang ditahan untuk order pending sampai dikonfirmasi, ditolak atau kadaluarsa
+type StockReservation struct {
+	ID        uint                   `gorm:"primaryKey" json:"id"`
+	OrderID   string                 `gorm:"index" json:"order_id"`
+	ProductID uint                   `gorm:"index" json:"product_id"`
+	Quantity  int                    `json:"quantity"`
+	Status    StockReservationStatus `gorm:"index;not null;default:active" json:"status"`
+	ExpiresAt time.Time              `gorm:"index" json:"expires_at"`
+	CreatedAt time.Time              `json:"created_at"`
+	UpdatedAt time.Time              `json:"updated_at"`
+}
@@ -0,0 +1,14 @@
+package domain
+
+// StoreProfile identitas toko untuk dokumen ke customer (invoice, pesan)
+type StoreProfile struct {
+	Name        string
+	Address     string
+	Phone       string
+	Email       string
+	PaymentInfo string
+	// OrderURL halaman order untuk customer, {id} diganti order ID
+	OrderURL string
+	// Language bahasa default pesan ke customer
+	Language string
+}
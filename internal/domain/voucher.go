@@ -0,0 +1,106 @@
+package domain
+
+import "time"
+
+type VoucherType string
+
+const (
+	VoucherTypePercentage VoucherType = "percentage"
+	VoucherTypeFixed      VoucherType = "fixed"
+)
+
+type Voucher struct {
+	ID                    uint        `gorm:"primaryKey" json:"id"`
+	Code                  string      `gorm:"uniqueIndex;not null" json:"code"`
+	Description           string      `json:"description"`
+	Type                  VoucherType `gorm:"not null" json:"type"`
+	Value                 float64     `json:"value"`
+	MinOrderValue         float64     `json:"min_order_value"`
+	MaxDiscount           float64     `json:"max_discount"`
+	StartsAt              *time.Time  `json:"starts_at"`
+	EndsAt                *time.Time  `json:"ends_at"`
+	UsageLimit            int         `json:"usage_limit"`
+	UsageLimitPerWhatsapp int         `json:"usage_limit_per_whatsapp"`
+	UsedCount             int         `gorm:"not null;default:0" json:"used_count"`
+	IsActive              bool        `gorm:"not null" json:"is_active"`
+	Categories            []Category  `gorm:"many2many:voucher_categories;" json:"categories"`
+	Products              []Product   `gorm:"many2many:voucher_products;" json:"products"`
+	CreatedAt             time.Time   `json:"created_at"`
+}
+
+// VoucherUsage mencatat setiap pemakaian voucher untuk limit per nomor WhatsApp
+type VoucherUsage struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	VoucherID uint      `gorm:"index;not null" json:"voucher_id"`
+	OrderID   string    `gorm:"index;not null" json:"order_id"`
+	Whatsapp  string    `gorm:"index;not null" json:"whatsapp"`
+	Discount  float64   `json:"discount"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Request DTOs
+type CreateVoucherRequest struct {
+	Code                  string      `json:"code" validate:"required,min=3,max=50,alphanum"`
+	Description           string      `json:"description" validate:"max=255"`
+	Type                  VoucherType `json:"type" validate:"required,oneof=percentage fixed"`
+	Value                 float64     `json:"value" validate:"required,gt=0,lte=999999999"`
+	MinOrderValue         float64     `json:"min_order_value" validate:"gte=0,lte=999999999"`
+	MaxDiscount           float64     `json:"max_discount" validate:"gte=0,lte=999999999"`
+	StartsAt              *time.Time  `json:"starts_at"`
+	EndsAt                *time.Time  `json:"ends_at"`
+	UsageLimit            int         `json:"usage_limit" validate:"gte=0"`
+	UsageLimitPerWhatsapp int         `json:"usage_limit_per_whatsapp" validate:"gte=0"`
+	IsActive              *bool       `json:"is_active"`
+	CategoryIDs           []uint      `json:"category_ids" validate:"max=100,dive,gt=0"`
+	ProductIDs            []uint      `json:"product_ids" validate:"max=100,dive,gt=0"`
+}
+
+type UpdateVoucherRequest struct {
+	Code                  string      `json:"code" validate:"required,min=3,max=50,alphanum"`
+	Description           string      `json:"description" validate:"max=255"`
+	Type                  VoucherType `json:"type" validate:"required,oneof=percentage fixed"`
+	Value                 float64     `json:"value" validate:"required,gt=0,lte=999999999"`
+	MinOrderValue         float64     `json:"min_order_value" validate:"gte=0,lte=999999999"`
+	MaxDiscount           float64     `json:"max_discount" validate:"gte=0,lte=999999999"`
+	StartsAt              *time.Time  `json:"starts_at"`
+	EndsAt                *time.Time  `json:"ends_at"`
+	UsageLimit            int         `json:"usage_limit" validate:"gte=0"`
+	UsageLimitPerWhatsapp int         `json:"usage_limit_per_whatsapp" validate:"gte=0"`
+	IsActive              *bool       `json:"is_active"`
+	CategoryIDs           []uint      `json:"category_ids" validate:"max=100,dive,gt=0"`
+	ProductIDs            []uint      `json:"product_ids" validate:"max=100,dive,gt=0"`
+}
+
+// Response DTOs
+type VoucherResponse struct {
+	ID                    uint        `json:"id"`
+	Code                  string      `json:"code"`
+	Description           string      `json:"description"`
+	Type                  VoucherType `json:"type"`
+	Value                 float64     `json:"value"`
+	MinOrderValue         float64     `json:"min_order_value"`
+	MaxDiscount           float64     `json:"max_discount"`
+	StartsAt              *string     `json:"starts_at"`
+	EndsAt                *string     `json:"ends_at"`
+	UsageLimit            int         `json:"usage_limit"`
+	UsageLimitPerWhatsapp int         `json:"usage_limit_per_whatsapp"`
+	UsedCount             int         `json:"used_count"`
+	IsActive              bool        `json:"is_active"`
+	CategoryIDs           []uint      `json:"category_ids"`
+	ProductIDs            []uint      `json:"product_ids"`
+	CreatedAt             string      `json:"created_at"`
+}
+
+type CreateVoucherResponse struct {
+	Message string          `json:"message"`
+	Voucher VoucherResponse `json:"voucher"`
+}
+
+type UpdateVoucherResponse struct {
+	Message string          `json:"message"`
+	Voucher VoucherResponse `json:"voucher"`
+}
+
+type DeleteVoucherResponse struct {
+	Message string `json:"message"`
+}
@@ -0,0 +1,209 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type WebhookEvent string
+
+const (
+	WebhookOrderCreated       WebhookEvent = "order.created"
+	WebhookOrderStatusChanged WebhookEvent = "order.status_changed"
+	WebhookOrderShipped       WebhookEvent = "order.shipped"
+	WebhookOrderCollected     WebhookEvent = "order.collected"
+	WebhookProductLowStock    WebhookEvent = "product.low_stock"
+)
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed"
+)
+
+// WebhookEndpoint URL milik integrasi luar yang menerima event, Events dipisah koma
+type WebhookEndpoint struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	URL         string    `gorm:"not null" json:"url"`
+	Description string    `json:"description"`
+	Events      string    `gorm:"not null" json:"events"`
+	Secret      string    `gorm:"not null" json:"-"`
+	IsActive    bool      `gorm:"not null" json:"is_active"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// WebhookDelivery satu event untuk satu endpoint, payload disimpan supaya bisa dikirim ulang apa adanya
+type WebhookDelivery struct {
+	ID             uint                  `gorm:"primaryKey" json:"id"`
+	EndpointID     uint                  `gorm:"index" json:"endpoint_id"`
+	Endpoint       *WebhookEndpoint      `gorm:"constraint:OnDelete:CASCADE;" json:"-"`
+	EventID        string                `gorm:"index" json:"event_id"`
+	Event          WebhookEvent          `json:"event"`
+	Payload        string                `gorm:"type:text;not null" json:"payload"`
+	Status         WebhookDeliveryStatus `gorm:"default:pending;index:idx_webhook_deliveries_status_next_attempt,priority:1" json:"status"`
+	Attempts       int                   `json:"attempts"`
+	NextAttemptAt  time.Time             `gorm:"index:idx_webhook_deliveries_status_next_attempt,priority:2" json:"next_attempt_at"`
+	LockedUntil    *time.Time            `json:"locked_until"`
+	ResponseStatus int                   `json:"response_status"`
+	LastError      string                `json:"last_error"`
+	DeliveredAt    *time.Time            `json:"delivered_at"`
+	AttemptLogs    []WebhookAttempt      `gorm:"foreignKey:DeliveryID;constraint:OnDelete:CASCADE;" json:"attempt_logs"`
+	CreatedAt      time.Time             `json:"created_at"`
+	UpdatedAt      time.Time             `json:"updated_at"`
+}
+
+// WebhookAttempt log satu kali percobaan kirim
+type WebhookAttempt struct {
+	ID             uint      `gorm:"primaryKey" json:"id"`
+	DeliveryID     uint      `gorm:"index" json:"delivery_id"`
+	Attempt        int       `json:"attempt"`
+	ResponseStatus int       `json:"response_status"`
+	ResponseBody   string    `gorm:"type:text" json:"response_body"`
+	Error          string    `json:"error"`
+	DurationMs     int64     `json:"duration_ms"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// WebhookPayload isi body yang dikirim ke endpoint
+type WebhookPayload struct {
+	ID        string       `json:"id"`
+	Event     WebhookEvent `json:"event"`
+	CreatedAt string       `json:"created_at"`
+	Data      interface{}  `json:"data"`
+}
+
+// WebhookOrder data order untuk webhook, tanpa pickup_code karena kode itu bukti pengambilan di toko
+type WebhookOrder struct {
+	ID               string                  `json:"id"`
+	OrderNumber      string                  `json:"order_number"`
+	CustomerID       *uint                   `json:"customer_id"`
+	CustomerName     string                  `json:"customer_name"`
+	Whatsapp         string                  `json:"whatsapp"`
+	Email            string                  `json:"email"`
+	Language         string                  `json:"language"`
+	MapAddress       string                  `json:"map_address"`
+	Latitude         float64                 `json:"latitude"`
+	Longitude        float64                 `json:"longitude"`
+	AddressNote      string                  `json:"address_note"`
+	FulfilmentMethod FulfilmentMethod        `json:"fulfilment_method"`
+	CollectedAt      *string                 `json:"collected_at"`
+	Subtotal         float64                 `json:"subtotal"`
+	DiscountTotal    float64                 `json:"discount_total"`
+	DistanceKm       float64                 `json:"distance_km"`
+	DeliveryFee      float64                 `json:"delivery_fee"`
+	DeliveryZone     string                  `json:"delivery_zone"`
+	DeliveryDate     *string                 `json:"delivery_date"`
+	DestinationCity  string                  `json:"destination_city"`
+	Courier          string                  `json:"courier"`
+	CourierService   string                  `json:"courier_service"`
+	ShippingWeight   int                     `json:"shipping_weight"`
+	AirwayBill       string                  `json:"airway_bill"`
+	TrackingStatus   string                  `json:"tracking_status"`
+	TotalPrice       float64                 `json:"total_price"`
+	VoucherCode      string                  `json:"voucher_code"`
+	PaymentMethod    PaymentMethod           `json:"payment_method"`
+	ProofOfPayment   string                  `json:"proof_of_payment"`
+	PaidAt           *string                 `json:"paid_at"`
+	Payments         []PaymentResponse       `json:"payments,omitempty"`
+	Status           OrderStatus             `json:"status"`
+	CancelReason     string                  `json:"cancel_reason,omitempty"`
+	CancelledAt      *string                 `json:"cancelled_at"`
+	OrderItems       []OrderItemResponse     `json:"order_items"`
+	Discounts        []OrderDiscountResponse `json:"discounts"`
+	CreatedAt        string                  `json:"created_at"`
+}
+
+// WebhookOrderStatusData data event order.status_changed
+type WebhookOrderStatusData struct {
+	PreviousStatus OrderStatus  `json:"previous_status"`
+	Order          WebhookOrder `json:"order"`
+}
+
+// WebhookLowStockData data event product.low_stock
+type WebhookLowStockData struct {
+	ProductID uint           `json:"product_id"`
+	Name      string         `json:"name"`
+	Available int            `json:"available"`
+	Threshold int            `json:"threshold"`
+	Status    LowStockStatus `json:"status"`
+}
+
+// Request DTOs
+type CreateWebhookEndpointRequest struct {
+	URL         string   `json:"url" validate:"required,http_url,max=500"`
+	Description string   `json:"description" validate:"max=255"`
+	Events      []string `json:"events" validate:"required,min=1,dive,oneof=order.created order.status_changed order.shipped order.collected product.low_stock"`
+	IsActive    *bool    `json:"is_active"`
+}
+
+type UpdateWebhookEndpointRequest struct {
+	URL         string   `json:"url" validate:"required,http_url,max=500"`
+	Description string   `json:"description" validate:"max=255"`
+	Events      []string `json:"events" validate:"required,min=1,dive,oneof=order.created order.status_changed order.shipped order.collected product.low_stock"`
+	IsActive    *bool    `json:"is_active"`
+}
+
+type WebhookDeliveryListQuery struct {
+	Status WebhookDeliveryStatus `query:"status" validate:"omitempty,oneof=pending succeeded failed"`
+	Event  WebhookEvent          `query:"event" validate:"omitempty,oneof=order.created order.status_changed order.shipped order.collected product.low_stock"`
+}
+
+// Response DTOs
+type WebhookEndpointResponse struct {
+	ID          uint     `json:"id"`
+	URL         string   `json:"url"`
+	Description string   `json:"description"`
+	Events      []string `json:"events"`
+	IsActive    bool     `json:"is_active"`
+	// Secret hanya ditampilkan di detail dan saat endpoint dibuat
+	Secret    string `json:"secret,omitempty"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+type WebhookAttemptResponse struct {
+	Attempt        int    `json:"attempt"`
+	ResponseStatus int    `json:"response_status"`
+	ResponseBody   string `json:"response_body,omitempty"`
+	Error          string `json:"error,omitempty"`
+	DurationMs     int64  `json:"duration_ms"`
+	CreatedAt      string `json:"created_at"`
+}
+
+type WebhookDeliveryResponse struct {
+	ID             uint                     `json:"id"`
+	EndpointID     uint                     `json:"endpoint_id"`
+	EventID        string                   `json:"event_id"`
+	Event          WebhookEvent             `json:"event"`
+	Payload        json.RawMessage          `json:"payload,omitempty"`
+	Status         WebhookDeliveryStatus    `json:"status"`
+	Attempts       int                      `json:"attempts"`
+	NextAttemptAt  *string                  `json:"next_attempt_at"`
+	ResponseStatus int                      `json:"response_status"`
+	LastError      string                   `json:"last_error,omitempty"`
+	DeliveredAt    *string                  `json:"delivered_at"`
+	AttemptLogs    []WebhookAttemptResponse `json:"attempt_logs,omitempty"`
+	CreatedAt      string                   `json:"created_at"`
+}
+
+type CreateWebhookEndpointResponse struct {
+	Message  string                  `json:"message"`
+	Endpoint WebhookEndpointResponse `json:"endpoint"`
+}
+
+type UpdateWebhookEndpointResponse struct {
+	Message  string                  `json:"message"`
+	Endpoint WebhookEndpointResponse `json:"endpoint"`
+}
+
+type DeleteWebhookEndpointResponse struct {
+	Message string `json:"message"`
+}
+
+type RedeliverWebhookResponse struct {
+	Message  string                  `json:"message"`
+	Delivery WebhookDeliveryResponse `json:"delivery"`
+}
@@ -0,0 +1,66 @@
+package infrastructure
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+)
+
+// AlertNotifier kirim alert operasional ke admin toko (stok menipis, dll)
+type AlertNotifier interface {
+	SendAlert(subject, message string) error
+}
+
+// LogAlertNotifier hanya menulis alert ke log server
+type LogAlertNotifier struct{}
+
+func (LogAlertNotifier) SendAlert(subject, message string) error {
+	log.Printf("ALERT %s: %s", subject, message)
+	return nil
+}
+
+// WebhookAlertNotifier POST JSON {subject, message} ke URL, misalnya incoming webhook Slack/Discord
+type WebhookAlertNotifier struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookAlertNotifier(url string) *WebhookAlertNotifier {
+	return &WebhookAlertNotifier{
+		url:    url,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (n *WebhookAlertNotifier) SendAlert(subject, message string) error {
+	body, err := json.Marshal(map[string]string{
+		"subject": subject,
+		"message": message,
+		"text":    subject + ": " + message,
+	})
+	if err != nil {
+		return err
+	}
+
+	resp, err := n.client.Post(n.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return errors.New("alert webhook returned " + resp.Status)
+	}
+	return nil
+}
+
+// NewAlertNotifier webhook jika ALERT_WEBHOOK_URL diisi, selain itu log
+func NewAlertNotifier() AlertNotifier {
+	if url := GetEnv("ALERT_WEBHOOK_URL"); url != "" {
+		return NewWebhookAlertNotifier(url)
+	}
+	return LogAlertNotifier{}
+}
@@ -3,6 +3,8 @@ package infrastructure
 import (
 	"log"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -17,3 +19,60 @@ func LoadEnv() {
 func GetEnv(key string) string {
 	return os.Getenv(key)
 }
+
+func getEnvDefault(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// GetEnvInt baca env sebagai int, pakai fallback jika kosong atau tidak valid
+func GetEnvInt(key string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
+// GetEnvFloat baca env sebagai float64, pakai fallback jika kosong atau tidak valid
+func GetEnvFloat(key string, fallback float64) float64 {
+	value, err := strconv.ParseFloat(os.Getenv(key), 64)
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
+// GetEnvDuration baca env sebagai time.Duration (mis. "30m", "2h"), pakai fallback jika kosong atau tidak valid
+func GetEnvDuration(key string, fallback time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
+// LoadOrderTimeouts umur order pending sebelum dibatalkan otomatis (0 = tidak dibatalkan) dan
+// lama stok ditahan. Umur auto-cancel dibaca dari ORDER_AUTO_CANCEL_AFTER (durasi, mis. "30m"),
+// jika kosong dari ORDER_AUTO_CANCEL_HOURS. Reservasi tidak boleh lebih pendek dari umur auto-cancel,
+// supaya stok order yang masih menunggu konfirmasi tidak terjual ke order lain lebih dulu.
+func LoadOrderTimeouts() (autoCancel, reservationTTL time.Duration) {
+	autoCancel = GetEnvDuration("ORDER_AUTO_CANCEL_AFTER", time.Duration(GetEnvInt("ORDER_AUTO_CANCEL_HOURS", 48))*time.Hour)
+	if autoCancel < 0 {
+		autoCancel = 0
+	}
+
+	defaultTTLHours := 24
+	if autoCancel > 0 {
+		// Default dibulatkan ke atas ke jam penuh
+		defaultTTLHours = int((autoCancel + time.Hour - 1) / time.Hour)
+	}
+	reservationTTL = time.Duration(GetEnvInt("STOCK_RESERVATION_TTL_HOURS", defaultTTLHours)) * time.Hour
+	if autoCancel > 0 && reservationTTL < autoCancel {
+		log.Printf("STOCK_RESERVATION_TTL_HOURS is shorter than the auto-cancel age %s, using %s", autoCancel, autoCancel)
+		reservationTTL = autoCancel
+	}
+	return autoCancel, reservationTTL
+}
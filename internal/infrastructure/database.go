@@ -16,8 +16,9 @@ func SetupDB() *gorm.DB {
 	dbName := GetEnv("DB_NAME")
 	dbPort := GetEnv("DB_PORT")
 
-	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable TimeZone=Asia/Makassar",
-		dbHost, dbUser, dbPassword, dbName, dbPort)
+	// Timezone session database mengikuti STORE_TIMEZONE
+	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable TimeZone=%s",
+		dbHost, dbUser, dbPassword, dbName, dbPort, StoreLocation().String())
 	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
 
 	if err != nil {
@@ -30,8 +31,46 @@ func SetupDB() *gorm.DB {
 		&domain.Product{},
 		&domain.Order{},
 		&domain.OrderItem{},
+		&domain.Voucher{},
+		&domain.VoucherUsage{},
+		&domain.OrderDiscount{},
+		&domain.Promotion{},
+		&domain.IdempotencyKey{},
+		&domain.DeliveryRate{},
+		&domain.DeliveryZone{},
+		&domain.StockReservation{},
+		&domain.ScheduledJob{},
+		&domain.StockMovement{},
+		&domain.Customer{},
+		&domain.OrderSequence{},
+		&domain.Notification{},
+		&domain.NotificationAttempt{},
+		&domain.MessageTemplate{},
+		&domain.WebhookEndpoint{},
+		&domain.WebhookDelivery{},
+		&domain.WebhookAttempt{},
+		&domain.Payment{},
 	)
 
+	createSearchIndexes(db)
+
 	log.Println("Database connection established")
 	return db
 }
+
+// createSearchIndexes index yang tidak bisa dibuat lewat tag GORM, untuk pencarian order admin.
+// Trigram untuk ILIKE '%...%' butuh extension pg_trgm, jika gagal pencarian tetap jalan tanpa index.
+func createSearchIndexes(db *gorm.DB) {
+	statements := []string{
+		"CREATE INDEX IF NOT EXISTS idx_orders_id_pattern ON orders (id text_pattern_ops)",
+		"CREATE INDEX IF NOT EXISTS idx_orders_order_number_pattern ON orders (order_number text_pattern_ops)",
+		"CREATE EXTENSION IF NOT EXISTS pg_trgm",
+		"CREATE INDEX IF NOT EXISTS idx_orders_customer_name_trgm ON orders USING gin (customer_name gin_trgm_ops)",
+		"CREATE INDEX IF NOT EXISTS idx_orders_whatsapp_trgm ON orders USING gin (whatsapp gin_trgm_ops)",
+	}
+	for _, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			log.Println("Skipping search index:", err)
+		}
+	}
+}
@@ -0,0 +1,215 @@
+package infrastructure
+
+import (
+	"butik/internal/domain"
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+)
+
+// InvoiceRenderer render invoice order menjadi dokumen siap unduh
+type InvoiceRenderer interface {
+	RenderInvoice(order *domain.Order) ([]byte, error)
+}
+
+// PDFInvoiceRenderer invoice PDF A4, dibuat di dalam proses tanpa layanan eksternal
+type PDFInvoiceRenderer struct {
+	store    domain.StoreProfile
+	location *time.Location
+}
+
+func NewPDFInvoiceRenderer(store domain.StoreProfile, location *time.Location) *PDFInvoiceRenderer {
+	return &PDFInvoiceRenderer{store: store, location: location}
+}
+
+func (r *PDFInvoiceRenderer) RenderInvoice(order *domain.Order) ([]byte, error) {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(15, 15, 15)
+	pdf.SetAutoPageBreak(true, 20)
+	pdf.AddPage()
+	// Font bawaan hanya cp1252, teks UTF-8 dikonversi dulu
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	title := "INVOICE"
+	if order.Status == domain.OrderStatusSuccess {
+		title = "RECEIPT"
+	}
+
+	// Header toko
+	pdf.SetFont("Helvetica", "B", 18)
+	pdf.CellFormat(110, 9, tr(r.store.Name), "", 0, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "B", 16)
+	pdf.CellFormat(70, 9, title, "", 1, "R", false, 0, "")
+
+	pdf.SetFont("Helvetica", "", 9)
+	contact := strings.Join(nonEmpty(r.store.Phone, r.store.Email), "  |  ")
+	for _, line := range nonEmpty(r.store.Address, contact) {
+		pdf.CellFormat(110, 5, tr(line), "", 1, "L", false, 0, "")
+	}
+	pdf.Ln(4)
+
+	// Info invoice dan customer
+	createdAt := order.CreatedAt.In(r.location).Format("02 Jan 2006 15:04")
+	left := []string{
+		"Billed to:",
+		order.CustomerName,
+		order.Whatsapp,
+	}
+	if order.FulfilmentMethod == domain.FulfilmentPickup {
+		left = append(left, "Store pickup")
+	} else {
+		left = append(left, nonEmpty(order.MapAddress, order.AddressNote)...)
+	}
+	// Order lama belum punya nomor urut
+	invoiceNumber := order.OrderNumber
+	if invoiceNumber == "" {
+		invoiceNumber = order.ID
+	}
+	right := []string{
+		"Invoice no: " + invoiceNumber,
+		"Date: " + createdAt,
+		"Status: " + strings.ToUpper(string(order.Status)),
+	}
+
+	startY := pdf.GetY()
+	pdf.SetFont("Helvetica", "", 10)
+	for i, line := range left {
+		if i == 0 {
+			pdf.SetFont("Helvetica", "B", 10)
+		}
+		pdf.MultiCell(100, 5, tr(line), "", "L", false)
+		pdf.SetFont("Helvetica", "", 10)
+	}
+	leftEndY := pdf.GetY()
+
+	pdf.SetXY(115, startY)
+	for _, line := range right {
+		pdf.SetX(115)
+		pdf.CellFormat(80, 5, tr(line), "", 1, "R", false, 0, "")
+	}
+	if pdf.GetY() < leftEndY {
+		pdf.SetY(leftEndY)
+	}
+	pdf.Ln(6)
+
+	// Tabel item
+	widths := []float64{10, 90, 15, 32.5, 32.5}
+	pdf.SetFont("Helvetica", "B", 10)
+	pdf.SetFillColor(235, 235, 235)
+	for i, heading := range []string{"No", "Product", "Qty", "Price", "Total"} {
+		align := "L"
+		if i >= 2 {
+			align = "R"
+		}
+		pdf.CellFormat(widths[i], 8, heading, "B", 0, align, true, 0, "")
+	}
+	pdf.Ln(-1)
+
+	pdf.SetFont("Helvetica", "", 10)
+	for i, item := range order.OrderItems {
+		name := item.Product.Name
+		if name == "" {
+			name = "Product #" + strconv.FormatUint(uint64(item.ProductID), 10)
+		}
+		pdf.CellFormat(widths[0], 7, strconv.Itoa(i+1), "", 0, "L", false, 0, "")
+		pdf.CellFormat(widths[1], 7, tr(name), "", 0, "L", false, 0, "")
+		pdf.CellFormat(widths[2], 7, strconv.Itoa(item.Quantity), "", 0, "R", false, 0, "")
+		pdf.CellFormat(widths[3], 7, FormatRupiah(item.PriceAtPurchase), "", 0, "R", false, 0, "")
+		pdf.CellFormat(widths[4], 7, FormatRupiah(item.PriceAtPurchase*float64(item.Quantity)), "", 1, "R", false, 0, "")
+	}
+	pdf.CellFormat(180, 1, "", "T", 1, "", false, 0, "")
+	pdf.Ln(2)
+
+	// Total
+	totalRow := func(label, value string, bold bool) {
+		style := ""
+		if bold {
+			style = "B"
+		}
+		pdf.SetFont("Helvetica", style, 10)
+		pdf.CellFormat(147.5, 6, tr(label), "", 0, "R", false, 0, "")
+		pdf.CellFormat(32.5, 6, value, "", 1, "R", false, 0, "")
+	}
+	totalRow("Subtotal", FormatRupiah(order.Subtotal), false)
+	for _, discount := range order.Discounts {
+		totalRow("Discount "+discount.Code, "- "+FormatRupiah(discount.Amount), false)
+	}
+	if order.FulfilmentMethod != domain.FulfilmentPickup {
+		label := "Delivery fee"
+		if order.Courier != "" {
+			label += " (" + strings.ToUpper(order.Courier) + " " + order.CourierService + ")"
+		} else if order.DeliveryZone != "" {
+			label += " (" + order.DeliveryZone + ")"
+		}
+		totalRow(label, FormatRupiah(order.DeliveryFee), false)
+	}
+	totalRow("Total", FormatRupiah(order.TotalPrice), true)
+	pdf.Ln(6)
+
+	// Info pembayaran
+	pdf.SetFont("Helvetica", "B", 10)
+	pdf.CellFormat(180, 6, "Payment", "", 1, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 10)
+	switch order.Status {
+	case domain.OrderStatusSuccess:
+		pdf.MultiCell(180, 5, "Paid by bank transfer. Thank you for your order.", "", "L", false)
+	case domain.OrderStatusPending:
+		payment := "Bank transfer, waiting for verification by the store."
+		if r.store.PaymentInfo != "" {
+			payment += "\n" + r.store.PaymentInfo
+		}
+		pdf.MultiCell(180, 5, tr(payment), "", "L", false)
+	default:
+		note := "This order is " + string(order.Status) + "."
+		if order.CancelReason != "" {
+			note += " Reason: " + order.CancelReason
+		}
+		pdf.MultiCell(180, 5, tr(note), "", "L", false)
+	}
+
+	pdf.SetY(-25)
+	pdf.SetFont("Helvetica", "I", 8)
+	pdf.CellFormat(180, 5, "Generated "+time.Now().In(r.location).Format("02 Jan 2006 15:04"), "", 0, "C", false, 0, "")
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("failed to render invoice: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// FormatRupiah format angka dengan pemisah ribuan titik, contoh Rp 150.000
+func FormatRupiah(amount float64) string {
+	negative := amount < 0
+	if negative {
+		amount = -amount
+	}
+
+	digits := strconv.FormatInt(int64(amount+0.5), 10)
+	var b strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte('.')
+		}
+		b.WriteRune(d)
+	}
+
+	if negative {
+		return "-Rp " + b.String()
+	}
+	return "Rp " + b.String()
+}
+
+func nonEmpty(values ...string) []string {
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		if strings.TrimSpace(value) != "" {
+			result = append(result, value)
+		}
+	}
+	return result
+}
@@ -0,0 +1,176 @@
+package infrastructure
+
+import (
+	"butik/internal/domain"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"mime"
+	"net/http"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// NotificationMessage pesan yang sudah dirender untuk satu penerima
+type NotificationMessage struct {
+	Recipient string
+	Subject   string
+	Body      string
+}
+
+// NotificationProvider pengirim pesan ke customer untuk satu channel
+type NotificationProvider interface {
+	Name() string
+	Channel() domain.NotificationChannel
+	Send(message NotificationMessage) error
+}
+
+// LogNotificationProvider hanya menulis pesan ke log, untuk development lokal
+type LogNotificationProvider struct {
+	channel domain.NotificationChannel
+}
+
+func NewLogNotificationProvider(channel domain.NotificationChannel) *LogNotificationProvider {
+	return &LogNotificationProvider{channel: channel}
+}
+
+func (p *LogNotificationProvider) Name() string {
+	return "log"
+}
+
+func (p *LogNotificationProvider) Channel() domain.NotificationChannel {
+	return p.channel
+}
+
+func (p *LogNotificationProvider) Send(message NotificationMessage) error {
+	log.Printf("NOTIFY %s to %s: %s %s", p.channel, message.Recipient, message.Subject, message.Body)
+	return nil
+}
+
+// WhatsAppGatewayProvider kirim lewat HTTP gateway WhatsApp.
+// POST JSON {"phone": "6281234567890", "message": "..."} dengan header Authorization token.
+type WhatsAppGatewayProvider struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+func NewWhatsAppGatewayProvider(url, token string) *WhatsAppGatewayProvider {
+	return &WhatsAppGatewayProvider{
+		url:    url,
+		token:  token,
+		client: &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+func (p *WhatsAppGatewayProvider) Name() string {
+	return "whatsapp_gateway"
+}
+
+func (p *WhatsAppGatewayProvider) Channel() domain.NotificationChannel {
+	return domain.NotificationChannelWhatsapp
+}
+
+func (p *WhatsAppGatewayProvider) Send(message NotificationMessage) error {
+	body, err := json.Marshal(map[string]string{
+		// Gateway umumnya menerima nomor tanpa +
+		"phone":   strings.TrimPrefix(message.Recipient, "+"),
+		"message": message.Body,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if p.token != "" {
+		req.Header.Set("Authorization", p.token)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return errors.New("whatsapp gateway returned " + resp.Status)
+	}
+	return nil
+}
+
+// SMTPEmailProvider kirim email teks biasa lewat SMTP (STARTTLS jika server mendukung)
+type SMTPEmailProvider struct {
+	addr string
+	auth smtp.Auth
+	from string
+}
+
+func NewSMTPEmailProvider(host string, port int, username, password, from string) *SMTPEmailProvider {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+	return &SMTPEmailProvider{
+		addr: host + ":" + strconv.Itoa(port),
+		auth: auth,
+		from: from,
+	}
+}
+
+func (p *SMTPEmailProvider) Name() string {
+	return "smtp"
+}
+
+func (p *SMTPEmailProvider) Channel() domain.NotificationChannel {
+	return domain.NotificationChannelEmail
+}
+
+func (p *SMTPEmailProvider) Send(message NotificationMessage) error {
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", p.from)
+	fmt.Fprintf(&msg, "To: %s\r\n", message.Recipient)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", message.Subject))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	msg.WriteString(strings.ReplaceAll(message.Body, "\n", "\r\n"))
+
+	return smtp.SendMail(p.addr, p.auth, p.from, []string{message.Recipient}, msg.Bytes())
+}
+
+// NewNotificationProviders provider per channel dari WHATSAPP_PROVIDER dan EMAIL_PROVIDER
+// (gateway/smtp, log, none). Default log supaya pesan terlihat saat development.
+func NewNotificationProviders() []NotificationProvider {
+	var providers []NotificationProvider
+
+	switch getEnvDefault("WHATSAPP_PROVIDER", "log") {
+	case "gateway":
+		url := GetEnv("WHATSAPP_GATEWAY_URL")
+		if url == "" {
+			log.Fatal("WHATSAPP_GATEWAY_URL is required for WHATSAPP_PROVIDER=gateway")
+		}
+		providers = append(providers, NewWhatsAppGatewayProvider(url, GetEnv("WHATSAPP_GATEWAY_TOKEN")))
+	case "log":
+		providers = append(providers, NewLogNotificationProvider(domain.NotificationChannelWhatsapp))
+	}
+
+	switch getEnvDefault("EMAIL_PROVIDER", "log") {
+	case "smtp":
+		host := GetEnv("SMTP_HOST")
+		if host == "" {
+			log.Fatal("SMTP_HOST is required for EMAIL_PROVIDER=smtp")
+		}
+		providers = append(providers, NewSMTPEmailProvider(host, GetEnvInt("SMTP_PORT", 587), GetEnv("SMTP_USERNAME"), GetEnv("SMTP_PASSWORD"), GetEnv("SMTP_FROM")))
+	case "log":
+		providers = append(providers, NewLogNotificationProvider(domain.NotificationChannelEmail))
+	}
+
+	return providers
+}
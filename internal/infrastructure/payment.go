@@ -0,0 +1,317 @@
+package infrastructure
+
+import (
+	"butik/internal/domain"
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ChargeRequest tagihan baru di payment gateway, Reference unik per tagihan
+type ChargeRequest struct {
+	Reference    string
+	Amount       int64
+	CustomerName string
+	Email        string
+	Phone        string
+	Expiry       time.Duration
+}
+
+// PaymentCharge hasil pembuatan tagihan, customer membayar lewat PaymentURL
+type PaymentCharge struct {
+	PaymentURL string
+	ExpiresAt  *time.Time
+}
+
+// PaymentState status tagihan menurut provider, dari callback maupun query status
+type PaymentState struct {
+	Reference string               `json:"reference"`
+	Status    domain.PaymentStatus `json:"status"`
+	Amount    int64                `json:"amount"`
+}
+
+// PaymentProvider adapter payment gateway
+type PaymentProvider interface {
+	Name() string
+	CreateCharge(req ChargeRequest) (*PaymentCharge, error)
+	// VerifyCallback cek signature notifikasi dari provider dan baca isinya
+	VerifyCallback(header http.Header, body []byte) (*PaymentState, error)
+	GetStatus(reference string) (*PaymentState, error)
+}
+
+// MidtransPaymentProvider Snap API Midtrans. Notifikasi ditandatangani dengan
+// signature_key = SHA512(order_id + status_code + gross_amount + server key).
+type MidtransPaymentProvider struct {
+	serverKey string
+	snapURL   string
+	apiURL    string
+	client    *http.Client
+}
+
+func NewMidtransPaymentProvider(serverKey string, production bool) *MidtransPaymentProvider {
+	provider := &MidtransPaymentProvider{
+		serverKey: serverKey,
+		snapURL:   "https://app.sandbox.midtrans.com/snap/v1/transactions",
+		apiURL:    "https://api.sandbox.midtrans.com",
+		client:    &http.Client{Timeout: 15 * time.Second},
+	}
+	if production {
+		provider.snapURL = "https://app.midtrans.com/snap/v1/transactions"
+		provider.apiURL = "https://api.midtrans.com"
+	}
+	return provider
+}
+
+func (p *MidtransPaymentProvider) Name() string {
+	return "midtrans"
+}
+
+// midtransTransaction bentuk notifikasi dan response status Midtrans
+type midtransTransaction struct {
+	OrderID           string `json:"order_id"`
+	StatusCode        string `json:"status_code"`
+	GrossAmount       string `json:"gross_amount"`
+	SignatureKey      string `json:"signature_key"`
+	TransactionStatus string `json:"transaction_status"`
+	FraudStatus       string `json:"fraud_status"`
+	StatusMessage     string `json:"status_message"`
+}
+
+func (p *MidtransPaymentProvider) CreateCharge(req ChargeRequest) (*PaymentCharge, error) {
+	payload := map[string]interface{}{
+		"transaction_details": map[string]interface{}{
+			"order_id":     req.Reference,
+			"gross_amount": req.Amount,
+		},
+		"customer_details": map[string]string{
+			"first_name": req.CustomerName,
+			"email":      req.Email,
+			"phone":      req.Phone,
+		},
+	}
+	if req.Expiry > 0 {
+		payload["expiry"] = map[string]interface{}{
+			"unit":     "minute",
+			"duration": int(req.Expiry.Minutes()),
+		}
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequest(http.MethodPost, p.snapURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", "application/json")
+	httpReq.SetBasicAuth(p.serverKey, "")
+
+	resp, err := p.client.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Token         string   `json:"token"`
+		RedirectURL   string   `json:"redirect_url"`
+		ErrorMessages []string `json:"error_messages"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, errors.New("midtrans returned " + resp.Status)
+	}
+	if resp.StatusCode >= 300 || result.RedirectURL == "" {
+		return nil, fmt.Errorf("midtrans returned %s: %v", resp.Status, result.ErrorMessages)
+	}
+
+	charge := &PaymentCharge{PaymentURL: result.RedirectURL}
+	if req.Expiry > 0 {
+		expiresAt := time.Now().Add(req.Expiry)
+		charge.ExpiresAt = &expiresAt
+	}
+	return charge, nil
+}
+
+func (p *MidtransPaymentProvider) VerifyCallback(header http.Header, body []byte) (*PaymentState, error) {
+	var notification midtransTransaction
+	if err := json.Unmarshal(body, &notification); err != nil {
+		return nil, domain.RejectPayment("invalid payment callback body")
+	}
+	if !p.validSignature(notification) {
+		return nil, domain.ErrInvalidPaymentSignature
+	}
+	return p.state(notification)
+}
+
+func (p *MidtransPaymentProvider) GetStatus(reference string) (*PaymentState, error) {
+	httpReq, err := http.NewRequest(http.MethodGet, p.apiURL+"/v2/"+url.PathEscape(reference)+"/status", nil)
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Accept", "application/json")
+	httpReq.SetBasicAuth(p.serverKey, "")
+
+	resp, err := p.client.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var transaction midtransTransaction
+	if err := json.NewDecoder(resp.Body).Decode(&transaction); err != nil {
+		return nil, errors.New("midtrans returned " + resp.Status)
+	}
+	// Status 404 berarti customer belum memilih metode bayar di halaman Snap
+	if transaction.StatusCode == "404" {
+		return &PaymentState{Reference: reference, Status: domain.PaymentStatusPending}, nil
+	}
+	if resp.StatusCode >= 300 || transaction.OrderID == "" {
+		return nil, fmt.Errorf("midtrans returned %s: %s", transaction.StatusCode, transaction.StatusMessage)
+	}
+	return p.state(transaction)
+}
+
+func (p *MidtransPaymentProvider) validSignature(transaction midtransTransaction) bool {
+	sum := sha512.Sum512([]byte(transaction.OrderID + transaction.StatusCode + transaction.GrossAmount + p.serverKey))
+	expected := hex.EncodeToString(sum[:])
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(transaction.SignatureKey)) == 1
+}
+
+func (p *MidtransPaymentProvider) state(transaction midtransTransaction) (*PaymentState, error) {
+	amount, err := strconv.ParseFloat(transaction.GrossAmount, 64)
+	if err != nil {
+		return nil, domain.RejectPayment("invalid payment amount")
+	}
+
+	status := domain.PaymentStatusPending
+	switch transaction.TransactionStatus {
+	case "settlement":
+		status = domain.PaymentStatusPaid
+	case "capture":
+		// Pembayaran kartu yang ditandai challenge masih menunggu review di dashboard Midtrans
+		if transaction.FraudStatus == "" || transaction.FraudStatus == "accept" {
+			status = domain.PaymentStatusPaid
+		}
+	case "deny", "cancel", "failure":
+		status = domain.PaymentStatusFailed
+	case "expire":
+		status = domain.PaymentStatusExpired
+	}
+
+	return &PaymentState{
+		Reference: transaction.OrderID,
+		Status:    status,
+		Amount:    int64(math.Round(amount)),
+	}, nil
+}
+
+// FakePaymentProvider payment gateway lokal untuk development, status disimpan di memori.
+// Callback ditandatangani dengan header X-Fake-Signature = hex(HMAC-SHA256(secret, body)).
+type FakePaymentProvider struct {
+	secret string
+	mu     sync.Mutex
+	states map[string]PaymentState
+}
+
+func NewFakePaymentProvider(secret string) *FakePaymentProvider {
+	return &FakePaymentProvider{
+		secret: secret,
+		states: make(map[string]PaymentState),
+	}
+}
+
+func (p *FakePaymentProvider) Name() string {
+	return "fake"
+}
+
+func (p *FakePaymentProvider) CreateCharge(req ChargeRequest) (*PaymentCharge, error) {
+	p.mu.Lock()
+	p.states[req.Reference] = PaymentState{Reference: req.Reference, Status: domain.PaymentStatusPending, Amount: req.Amount}
+	p.mu.Unlock()
+
+	log.Printf("FAKE PAYMENT %s created for %d", req.Reference, req.Amount)
+	charge := &PaymentCharge{PaymentURL: "/payments/fake/" + req.Reference}
+	if req.Expiry > 0 {
+		expiresAt := time.Now().Add(req.Expiry)
+		charge.ExpiresAt = &expiresAt
+	}
+	return charge, nil
+}
+
+func (p *FakePaymentProvider) VerifyCallback(header http.Header, body []byte) (*PaymentState, error) {
+	expected := p.sign(body)
+	if subtle.ConstantTimeCompare([]byte(expected), []byte(header.Get("X-Fake-Signature"))) != 1 {
+		return nil, domain.ErrInvalidPaymentSignature
+	}
+
+	var state PaymentState
+	if err := json.Unmarshal(body, &state); err != nil {
+		return nil, domain.RejectPayment("invalid payment callback body")
+	}
+	return &state, nil
+}
+
+func (p *FakePaymentProvider) GetStatus(reference string) (*PaymentState, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	state, ok := p.states[reference]
+	if !ok {
+		// Tagihan dari sebelum server restart, anggap masih menunggu
+		return &PaymentState{Reference: reference, Status: domain.PaymentStatusPending}, nil
+	}
+	return &state, nil
+}
+
+// Complete ubah status tagihan seolah customer sudah membayar, hasilnya callback yang sudah ditandatangani
+func (p *FakePaymentProvider) Complete(reference string, status domain.PaymentStatus, amount int64) (http.Header, []byte, error) {
+	state := PaymentState{Reference: reference, Status: status, Amount: amount}
+	p.mu.Lock()
+	p.states[reference] = state
+	p.mu.Unlock()
+
+	body, err := json.Marshal(state)
+	if err != nil {
+		return nil, nil, err
+	}
+	header := http.Header{}
+	header.Set("X-Fake-Signature", p.sign(body))
+	return header, body, nil
+}
+
+func (p *FakePaymentProvider) sign(body []byte) string {
+	mac := hmac.New(sha256.New, []byte(p.secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// NewPaymentProvider provider dari PAYMENT_PROVIDER (midtrans, fake), nil jika kosong
+// sehingga order hanya bisa dibayar dengan transfer manual
+func NewPaymentProvider() PaymentProvider {
+	switch GetEnv("PAYMENT_PROVIDER") {
+	case "midtrans":
+		serverKey := GetEnv("MIDTRANS_SERVER_KEY")
+		if serverKey == "" {
+			log.Fatal("MIDTRANS_SERVER_KEY is required for PAYMENT_PROVIDER=midtrans")
+		}
+		return NewMidtransPaymentProvider(serverKey, GetEnv("MIDTRANS_PRODUCTION") == "true")
+	case "fake":
+		return NewFakePaymentProvider(getEnvDefault("PAYMENT_FAKE_SECRET", "fake-secret"))
+	}
+	return nil
+}
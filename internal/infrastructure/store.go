@@ -0,0 +1,70 @@
+package infrastructure
+
+import (
+	"butik/internal/domain"
+	"log"
+	"strings"
+	"time"
+)
+
+const defaultStoreTimezone = "Asia/Makassar"
+
+// StoreLocation timezone toko dari STORE_TIMEZONE, default Asia/Makassar
+func StoreLocation() *time.Location {
+	name := GetEnv("STORE_TIMEZONE")
+	if name == "" {
+		name = defaultStoreTimezone
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		log.Fatal("Invalid STORE_TIMEZONE:", err)
+	}
+	return loc
+}
+
+// LoadDeliveryConfig, ongkir nonaktif jika STORE_LATITUDE/STORE_LONGITUDE kosong
+func LoadDeliveryConfig() domain.DeliveryConfig {
+	return domain.DeliveryConfig{
+		OriginLatitude:   GetEnvFloat("STORE_LATITUDE", 0),
+		OriginLongitude:  GetEnvFloat("STORE_LONGITUDE", 0),
+		MaxRadiusKm:      GetEnvFloat("DELIVERY_MAX_RADIUS_KM", 0),
+		FreeAboveTotal:   GetEnvFloat("DELIVERY_FREE_ABOVE", 0),
+		OriginConfigured: GetEnv("STORE_LATITUDE") != "" && GetEnv("STORE_LONGITUDE") != "",
+		OriginCity:       getEnvDefault("STORE_CITY", "makassar"),
+		Location:         StoreLocation(),
+	}
+}
+
+// LoadStoreProfile identitas toko dari env STORE_*
+func LoadStoreProfile() domain.StoreProfile {
+	language := getEnvDefault("STORE_LANGUAGE", domain.LanguageIndonesian)
+	if language != domain.LanguageIndonesian && language != domain.LanguageEnglish {
+		log.Fatal("Invalid STORE_LANGUAGE: must be id or en")
+	}
+
+	return domain.StoreProfile{
+		Name:        getEnvDefault("STORE_NAME", "Butik"),
+		Address:     GetEnv("STORE_ADDRESS"),
+		Phone:       GetEnv("STORE_PHONE"),
+		Email:       GetEnv("STORE_EMAIL"),
+		PaymentInfo: GetEnv("STORE_PAYMENT_INFO"),
+		OrderURL:    GetEnv("STORE_ORDER_URL"),
+		Language:    language,
+	}
+}
+
+const defaultOrderNumberPattern = "BTK-{YYYY}{MM}-{SEQ}"
+
+// LoadOrderNumberFormat pola nomor order dari ORDER_NUMBER_FORMAT, wajib memuat {SEQ}
+func LoadOrderNumberFormat(location *time.Location) domain.OrderNumberFormat {
+	pattern := getEnvDefault("ORDER_NUMBER_FORMAT", defaultOrderNumberPattern)
+	if strings.Count(pattern, domain.OrderNumberSeqToken) != 1 {
+		log.Fatal("Invalid ORDER_NUMBER_FORMAT: must contain {SEQ} exactly once")
+	}
+
+	return domain.OrderNumberFormat{
+		Pattern:  pattern,
+		Padding:  GetEnvInt("ORDER_NUMBER_PADDING", 5),
+		Location: location,
+	}
+}
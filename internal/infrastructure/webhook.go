@@ -0,0 +1,93 @@
+package infrastructure
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const webhookResponseLimit = 1024
+
+// WebhookRequest satu pengiriman event ke endpoint
+type WebhookRequest struct {
+	URL        string
+	Secret     string
+	Event      string
+	DeliveryID uint
+	Body       []byte
+}
+
+// WebhookResult response endpoint, StatusCode 0 jika request tidak sampai
+type WebhookResult struct {
+	StatusCode   int
+	ResponseBody string
+}
+
+// WebhookSender pengirim event ke endpoint integrasi luar
+type WebhookSender interface {
+	Send(req WebhookRequest) (WebhookResult, error)
+}
+
+// HTTPWebhookSender POST payload JSON dengan signature HMAC-SHA256.
+// Penerima memverifikasi X-Butik-Signature = "sha256=" + hex(HMAC(secret, timestamp + "." + body)).
+type HTTPWebhookSender struct {
+	client *http.Client
+}
+
+func NewHTTPWebhookSender(timeout time.Duration) *HTTPWebhookSender {
+	return &HTTPWebhookSender{client: &http.Client{Timeout: timeout}}
+}
+
+func (s *HTTPWebhookSender) Send(req WebhookRequest) (WebhookResult, error) {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	httpReq, err := http.NewRequest(http.MethodPost, req.URL, bytes.NewReader(req.Body))
+	if err != nil {
+		return WebhookResult{}, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("User-Agent", "Butik-Webhook/1.0")
+	httpReq.Header.Set("X-Butik-Event", req.Event)
+	httpReq.Header.Set("X-Butik-Delivery", strconv.FormatUint(uint64(req.DeliveryID), 10))
+	httpReq.Header.Set("X-Butik-Timestamp", timestamp)
+	httpReq.Header.Set("X-Butik-Signature", SignWebhook(req.Secret, timestamp, req.Body))
+
+	resp, err := s.client.Do(httpReq)
+	if err != nil {
+		return WebhookResult{}, err
+	}
+	defer resp.Body.Close()
+
+	// Body hanya disimpan sebagian untuk log, sisanya dibuang supaya koneksi bisa dipakai ulang
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, webhookResponseLimit))
+	io.Copy(io.Discard, resp.Body)
+
+	result := WebhookResult{StatusCode: resp.StatusCode, ResponseBody: string(body)}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return result, &WebhookStatusError{StatusCode: resp.StatusCode}
+	}
+	return result, nil
+}
+
+// WebhookStatusError endpoint membalas dengan status selain 2xx
+type WebhookStatusError struct {
+	StatusCode int
+}
+
+func (e *WebhookStatusError) Error() string {
+	return "endpoint returned " + strconv.Itoa(e.StatusCode) + " " + http.StatusText(e.StatusCode)
+}
+
+// SignWebhook signature untuk header X-Butik-Signature
+func SignWebhook(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"butik/internal/domain"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CustomerRepo interface {
+	// GetAllCustomers cari nama dengan search dan nomor dengan whatsappDigits (format tersimpan tanpa +)
+	GetAllCustomers(search, whatsappDigits, sortBy, sortOrder string, offset, limit int) ([]domain.CustomerSummary, int, error)
+	GetCustomerByID(id uint) (*domain.CustomerSummary, error)
+	GetCustomerAddresses(id uint, limit int) ([]domain.CustomerAddress, error)
+	GetUnlinkedOrders(limit int) ([]domain.Order, error)
+	LinkOrder(orderID string, customer domain.Customer) error
+}
+
+type customerRepo struct {
+	db *gorm.DB
+}
+
+func NewCustomerRepo(db *gorm.DB) CustomerRepo {
+	return &customerRepo{db: db}
+}
+
+const customerSummarySelect = `customers.*, COUNT(orders.id) AS order_count,
+	COALESCE(SUM(orders.total_price) FILTER (WHERE orders.status = 'success'), 0) AS total_spent,
+	MAX(orders.created_at) AS last_order_at`
+
+func (r *customerRepo) summaries() *gorm.DB {
+	return r.db.Table("customers").
+		Select(customerSummarySelect).
+		Joins("LEFT JOIN orders ON orders.customer_id = customers.id").
+		Group("customers.id")
+}
+
+func (r *customerRepo) GetAllCustomers(search, whatsappDigits, sortBy, sortOrder string, offset, limit int) ([]domain.CustomerSummary, int, error) {
+	filtered := func(query *gorm.DB) *gorm.DB {
+		if search == "" {
+			return query
+		}
+		if whatsappDigits == "" {
+			return query.Where("customers.name ILIKE ?", "%"+escapeLike(search)+"%")
+		}
+		return query.Where("customers.name ILIKE ? OR customers.whatsapp LIKE ?", "%"+escapeLike(search)+"%", "%"+whatsappDigits+"%")
+	}
+
+	var total int64
+	if err := filtered(r.db.Model(&domain.Customer{})).Count(&total).Error; err != nil {
+		return nil, 0, errors.New("failed to count customers")
+	}
+
+	var customers []domain.CustomerSummary
+	if err := filtered(r.summaries()).Order(customerSortClause(sortBy, sortOrder)).Offset(offset).Limit(limit).Scan(&customers).Error; err != nil {
+		return nil, 0, errors.New("failed to retrieve customers")
+	}
+	return customers, int(total), nil
+}
+
+func customerSortClause(sortBy, sortOrder string) string {
+	column := "last_order_at"
+	switch sortBy {
+	case "total_spent", "order_count":
+		column = sortBy
+	case "name":
+		column = "customers.name"
+	}
+
+	direction := "DESC"
+	if sortOrder == "asc" {
+		direction = "ASC"
+	}
+	return column + " " + direction + " NULLS LAST, customers.id " + direction
+}
+
+func (r *customerRepo) GetCustomerByID(id uint) (*domain.CustomerSummary, error) {
+	var customers []domain.CustomerSummary
+	if err := r.summaries().Where("customers.id = ?", id).Scan(&customers).Error; err != nil {
+		return nil, errors.New("failed to retrieve customer")
+	}
+	if len(customers) == 0 {
+		return nil, errors.New("customer not found")
+	}
+	return &customers[0], nil
+}
+
+func (r *customerRepo) GetCustomerAddresses(id uint, limit int) ([]domain.CustomerAddress, error) {
+	var addresses []domain.CustomerAddress
+	err := r.db.Model(&domain.Order{}).
+		Select("map_address, latitude, longitude, address_note, COUNT(*) AS order_count, MAX(created_at) AS last_used_at").
+		Where("customer_id = ? AND fulfilment_method = ? AND map_address <> ''", id, domain.FulfilmentDelivery).
+		Group("map_address, latitude, longitude, address_note").
+		Order("last_used_at DESC").
+		Limit(limit).
+		Scan(&addresses).Error
+	if err != nil {
+		return nil, errors.New("failed to retrieve customer addresses")
+	}
+	return addresses, nil
+}
+
+// GetUnlinkedOrders order lama yang dibuat sebelum ada tabel customer
+func (r *customerRepo) GetUnlinkedOrders(limit int) ([]domain.Order, error) {
+	var orders []domain.Order
+	err := r.db.Select("id, customer_name, whatsapp, created_at").
+		Where("customer_id IS NULL AND whatsapp ~ '[0-9]'").
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&orders).Error
+	if err != nil {
+		return nil, errors.New("failed to retrieve unlinked orders")
+	}
+	return orders, nil
+}
+
+// LinkOrder hubungkan order lama ke customer, nomor di order tidak diubah
+// dan nama customer yang sudah ada tidak ditimpa
+func (r *customerRepo) LinkOrder(orderID string, customer domain.Customer) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := upsertCustomer(tx, &customer, false); err != nil {
+			return err
+		}
+		err := tx.Model(&domain.Order{}).Where("id = ?", orderID).Update("customer_id", customer.ID).Error
+		if err != nil {
+			return errors.New("failed to link order to customer")
+		}
+		return nil
+	})
+}
+
+// upsertCustomer buat customer baru atau ambil yang sudah ada berdasarkan nomor WhatsApp
+func upsertCustomer(tx *gorm.DB, customer *domain.Customer, updateName bool) error {
+	columns := []string{"updated_at"}
+	if updateName {
+		columns = append(columns, "name")
+	}
+
+	err := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "whatsapp"}},
+		DoUpdates: clause.AssignmentColumns(columns),
+	}).Create(customer).Error
+	if err != nil || customer.ID == 0 {
+		return errors.New("failed to save customer")
+	}
+	return nil
+}
+
+// linkCustomer simpan customer dari order baru, nama mengikuti order terakhir
+func linkCustomer(tx *gorm.DB, order *domain.Order) error {
+	if order.Customer == nil {
+		return nil
+	}
+	if err := upsertCustomer(tx, order.Customer, true); err != nil {
+		return err
+	}
+	order.CustomerID = &order.Customer.ID
+	order.Customer = nil
+	return nil
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"butik/internal/domain"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+type DeliveryRateRepo interface {
+	CreateDeliveryRate(rate domain.DeliveryRate) (*domain.DeliveryRate, error)
+	GetAllDeliveryRates() ([]domain.DeliveryRate, error)
+	GetDeliveryRateByID(id uint) (*domain.DeliveryRate, error)
+	GetDeliveryRateForDistance(distanceKm float64) (*domain.DeliveryRate, error)
+	HasDeliveryRates() (bool, error)
+	UpdateDeliveryRate(id uint, rate domain.DeliveryRate) (*domain.DeliveryRate, error)
+	DeleteDeliveryRate(id uint) error
+}
+
+type deliveryRateRepo struct {
+	db *gorm.DB
+}
+
+func NewDeliveryRateRepo(db *gorm.DB) DeliveryRateRepo {
+	return &deliveryRateRepo{db: db}
+}
+
+func (r *deliveryRateRepo) CreateDeliveryRate(rate domain.DeliveryRate) (*domain.DeliveryRate, error) {
+	result := r.db.Create(&rate)
+	if result.Error != nil {
+		return nil, errors.New("failed to create delivery rate")
+	}
+	return &rate, nil
+}
+
+func (r *deliveryRateRepo) GetAllDeliveryRates() ([]domain.DeliveryRate, error) {
+	var rates []domain.DeliveryRate
+	if err := r.db.Order("max_distance_km ASC").Find(&rates).Error; err != nil {
+		return nil, errors.New("failed to retrieve delivery rates")
+	}
+	return rates, nil
+}
+
+func (r *deliveryRateRepo) GetDeliveryRateByID(id uint) (*domain.DeliveryRate, error) {
+	rate := &domain.DeliveryRate{}
+	result := r.db.First(rate, id)
+	if result.Error != nil {
+		return nil, errors.New("delivery rate not found")
+	}
+	return rate, nil
+}
+
+// GetDeliveryRateForDistance ambil band terkecil yang masih mencakup jarak
+func (r *deliveryRateRepo) GetDeliveryRateForDistance(distanceKm float64) (*domain.DeliveryRate, error) {
+	rate := &domain.DeliveryRate{}
+	result := r.db.Where("max_distance_km >= ?", distanceKm).Order("max_distance_km ASC").First(rate)
+	if result.Error != nil {
+		return nil, errors.New("no delivery rate for this distance")
+	}
+	return rate, nil
+}
+
+// HasDeliveryRates sudah ada band tarif atau belum
+func (r *deliveryRateRepo) HasDeliveryRates() (bool, error) {
+	var count int64
+	if err := r.db.Model(&domain.DeliveryRate{}).Limit(1).Count(&count).Error; err != nil {
+		return false, errors.New("failed to retrieve delivery rates")
+	}
+	return count > 0, nil
+}
+
+func (r *deliveryRateRepo) UpdateDeliveryRate(id uint, updatedRate domain.DeliveryRate) (*domain.DeliveryRate, error) {
+	rate, err := r.GetDeliveryRateByID(id)
+	if err != nil {
+		return nil, err
+	}
+	rate.MaxDistanceKm = updatedRate.MaxDistanceKm
+	rate.BaseFee = updatedRate.BaseFee
+	rate.PerKmFee = updatedRate.PerKmFee
+
+	result := r.db.Save(rate)
+	if result.Error != nil {
+		return nil, errors.New("failed to update delivery rate")
+	}
+	return rate, nil
+}
+
+func (r *deliveryRateRepo) DeleteDeliveryRate(id uint) error {
+	rate, err := r.GetDeliveryRateByID(id)
+	if err != nil {
+		return err
+	}
+	result := r.db.Delete(rate)
+	if result.Error != nil {
+		return errors.New("failed to delete delivery rate")
+	}
+	return nil
+}
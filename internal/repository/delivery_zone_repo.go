@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"butik/internal/domain"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+type DeliveryZoneRepo interface {
+	CreateDeliveryZone(zone domain.DeliveryZone) (*domain.DeliveryZone, error)
+	GetAllDeliveryZones() ([]domain.DeliveryZone, error)
+	GetActiveDeliveryZones() ([]domain.DeliveryZone, error)
+	GetDeliveryZoneByID(id uint) (*domain.DeliveryZone, error)
+	UpdateDeliveryZone(id uint, zone domain.DeliveryZone) (*domain.DeliveryZone, error)
+	DeleteDeliveryZone(id uint) error
+}
+
+type deliveryZoneRepo struct {
+	db *gorm.DB
+}
+
+func NewDeliveryZoneRepo(db *gorm.DB) DeliveryZoneRepo {
+	return &deliveryZoneRepo{db: db}
+}
+
+func (r *deliveryZoneRepo) CreateDeliveryZone(zone domain.DeliveryZone) (*domain.DeliveryZone, error) {
+	result := r.db.Create(&zone)
+	if result.Error != nil {
+		return nil, errors.New("failed to create delivery zone")
+	}
+	return &zone, nil
+}
+
+func (r *deliveryZoneRepo) GetAllDeliveryZones() ([]domain.DeliveryZone, error) {
+	var zones []domain.DeliveryZone
+	if err := r.db.Order("name ASC").Find(&zones).Error; err != nil {
+		return nil, errors.New("failed to retrieve delivery zones")
+	}
+	return zones, nil
+}
+
+// GetActiveDeliveryZones urut ongkir termurah dulu, dipakai jika zona saling tumpang tindih
+func (r *deliveryZoneRepo) GetActiveDeliveryZones() ([]domain.DeliveryZone, error) {
+	var zones []domain.DeliveryZone
+	if err := r.db.Where("is_active = ?", true).Order("fee ASC, id ASC").Find(&zones).Error; err != nil {
+		return nil, errors.New("failed to retrieve delivery zones")
+	}
+	return zones, nil
+}
+
+func (r *deliveryZoneRepo) GetDeliveryZoneByID(id uint) (*domain.DeliveryZone, error) {
+	zone := &domain.DeliveryZone{}
+	result := r.db.First(zone, id)
+	if result.Error != nil {
+		return nil, errors.New("delivery zone not found")
+	}
+	return zone, nil
+}
+
+func (r *deliveryZoneRepo) UpdateDeliveryZone(id uint, updatedZone domain.DeliveryZone) (*domain.DeliveryZone, error) {
+	zone, err := r.GetDeliveryZoneByID(id)
+	if err != nil {
+		return nil, err
+	}
+	zone.Name = updatedZone.Name
+	zone.Fee = updatedZone.Fee
+	zone.AllowedDays = updatedZone.AllowedDays
+	zone.IsActive = updatedZone.IsActive
+	zone.Geometry = updatedZone.Geometry
+
+	result := r.db.Save(zone)
+	if result.Error != nil {
+		return nil, errors.New("failed to update delivery zone")
+	}
+	return zone, nil
+}
+
+func (r *deliveryZoneRepo) DeleteDeliveryZone(id uint) error {
+	zone, err := r.GetDeliveryZoneByID(id)
+	if err != nil {
+		return err
+	}
+	result := r.db.Delete(zone)
+	if result.Error != nil {
+		return errors.New("failed to delete delivery zone")
+	}
+	return nil
+}
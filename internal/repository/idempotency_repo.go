@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"butik/internal/domain"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IdempotencyRepo interface {
+	// Reserve membuat key baru atau mengambil alih key processing yang lease-nya habis (created=true),
+	// selain itu mengembalikan key yang sudah ada
+	Reserve(key, scope, requestHash string, expiresAt, lockedUntil time.Time) (record *domain.IdempotencyKey, created bool, err error)
+	Complete(key, scope string, statusCode int, responseBody string) error
+	Release(key, scope string) error
+	// DeleteExpired hapus key yang sudah lewat retention, mengembalikan jumlah yang dihapus
+	DeleteExpired(now time.Time) (int, error)
+}
+
+type idempotencyRepo struct {
+	db *gorm.DB
+}
+
+func NewIdempotencyRepo(db *gorm.DB) IdempotencyRepo {
+	return &idempotencyRepo{db: db}
+}
+
+func (r *idempotencyRepo) Reserve(key, scope, requestHash string, expiresAt, lockedUntil time.Time) (*domain.IdempotencyKey, bool, error) {
+	now := time.Now()
+	// Key yang sudah lewat retention dianggap tidak pernah ada
+	if err := r.db.Where("key = ? AND scope = ? AND expires_at < ?", key, scope, now).Delete(&domain.IdempotencyKey{}).Error; err != nil {
+		return nil, false, errors.New("failed to clean up idempotency key")
+	}
+
+	record := &domain.IdempotencyKey{
+		Key:         key,
+		Scope:       scope,
+		RequestHash: requestHash,
+		Status:      domain.IdempotencyStatusProcessing,
+		LockedUntil: &lockedUntil,
+		ExpiresAt:   expiresAt,
+	}
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(record)
+	if result.Error != nil {
+		return nil, false, errors.New("failed to reserve idempotency key")
+	}
+	if result.RowsAffected == 1 {
+		return record, true, nil
+	}
+
+	// Request sebelumnya berhenti tanpa Complete/Release, retry dengan payload sama boleh melanjutkan
+	result = r.db.Model(&domain.IdempotencyKey{}).
+		Where("key = ? AND scope = ? AND request_hash = ? AND status = ? AND (locked_until IS NULL OR locked_until < ?)", key, scope, requestHash, domain.IdempotencyStatusProcessing, now).
+		Updates(map[string]interface{}{
+			"locked_until": lockedUntil,
+			"expires_at":   expiresAt,
+		})
+	if result.Error != nil {
+		return nil, false, errors.New("failed to reserve idempotency key")
+	}
+	if result.RowsAffected == 1 {
+		return record, true, nil
+	}
+
+	existing := &domain.IdempotencyKey{}
+	if err := r.db.Where("key = ? AND scope = ?", key, scope).First(existing).Error; err != nil {
+		return nil, false, errors.New("failed to load idempotency key")
+	}
+	return existing, false, nil
+}
+
+func (r *idempotencyRepo) Complete(key, scope string, statusCode int, responseBody string) error {
+	result := r.db.Model(&domain.IdempotencyKey{}).
+		Where("key = ? AND scope = ?", key, scope).
+		Updates(map[string]interface{}{
+			"status":        domain.IdempotencyStatusCompleted,
+			"status_code":   statusCode,
+			"response_body": responseBody,
+			"locked_until":  nil,
+		})
+	if result.Error != nil {
+		return errors.New("failed to store idempotent response")
+	}
+	return nil
+}
+
+func (r *idempotencyRepo) Release(key, scope string) error {
+	result := r.db.Where("key = ? AND scope = ?", key, scope).Delete(&domain.IdempotencyKey{})
+	if result.Error != nil {
+		return errors.New("failed to release idempotency key")
+	}
+	return nil
+}
+
+func (r *idempotencyRepo) DeleteExpired(now time.Time) (int, error) {
+	result := r.db.Where("expires_at < ?", now).Delete(&domain.IdempotencyKey{})
+	if result.Error != nil {
+		return 0, errors.New("failed to delete expired idempotency keys")
+	}
+	return int(result.RowsAffected), nil
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"butik/internal/domain"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type JobRepo interface {
+	EnsureJob(name string, nextRunAt time.Time) error
+	// AcquireJob mengunci job yang sudah jatuh tempo, false jika belum waktunya atau dipegang instance lain
+	AcquireJob(name, owner string, now, lockedUntil time.Time) (bool, error)
+	FinishJob(name, owner string, finishedAt, nextRunAt time.Time, lastError string) error
+}
+
+type jobRepo struct {
+	db *gorm.DB
+}
+
+func NewJobRepo(db *gorm.DB) JobRepo {
+	return &jobRepo{db: db}
+}
+
+func (r *jobRepo) EnsureJob(name string, nextRunAt time.Time) error {
+	job := &domain.ScheduledJob{
+		Name:      name,
+		NextRunAt: nextRunAt,
+	}
+	if err := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(job).Error; err != nil {
+		return errors.New("failed to register job")
+	}
+	return nil
+}
+
+func (r *jobRepo) AcquireJob(name, owner string, now, lockedUntil time.Time) (bool, error) {
+	// Update bersyarat bersifat atomic, hanya satu instance yang mendapat RowsAffected = 1
+	result := r.db.Model(&domain.ScheduledJob{}).
+		Where("name = ? AND next_run_at <= ? AND (locked_until IS NULL OR locked_until < ?)", name, now, now).
+		Updates(map[string]interface{}{
+			"locked_by":    owner,
+			"locked_until": lockedUntil,
+		})
+	if result.Error != nil {
+		return false, errors.New("failed to acquire job lock")
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *jobRepo) FinishJob(name, owner string, finishedAt, nextRunAt time.Time, lastError string) error {
+	result := r.db.Model(&domain.ScheduledJob{}).
+		Where("name = ? AND locked_by = ?", name, owner).
+		Updates(map[string]interface{}{
+			"locked_by":    "",
+			"locked_until": gorm.Expr("NULL"),
+			"last_run_at":  finishedAt,
+			"next_run_at":  nextRunAt,
+			"last_error":   lastError,
+		})
+	if result.Error != nil {
+		return errors.New("failed to release job lock")
+	}
+	return nil
+}
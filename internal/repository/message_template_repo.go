@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"butik/internal/domain"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type MessageTemplateRepo interface {
+	GetAllTemplates() ([]domain.MessageTemplate, error)
+	GetTemplate(event domain.NotificationEvent, language string) (*domain.MessageTemplate, error)
+	SaveTemplate(template domain.MessageTemplate) (*domain.MessageTemplate, error)
+	DeleteTemplate(event domain.NotificationEvent, language string) error
+}
+
+type messageTemplateRepo struct {
+	db *gorm.DB
+}
+
+func NewMessageTemplateRepo(db *gorm.DB) MessageTemplateRepo {
+	return &messageTemplateRepo{db: db}
+}
+
+func (r *messageTemplateRepo) GetAllTemplates() ([]domain.MessageTemplate, error) {
+	var templates []domain.MessageTemplate
+	if err := r.db.Order("event ASC, language ASC").Find(&templates).Error; err != nil {
+		return nil, errors.New("failed to retrieve message templates")
+	}
+	return templates, nil
+}
+
+// GetTemplate nil tanpa error jika admin belum mengubah template ini
+func (r *messageTemplateRepo) GetTemplate(event domain.NotificationEvent, language string) (*domain.MessageTemplate, error) {
+	var templates []domain.MessageTemplate
+	if err := r.db.Where("event = ? AND language = ?", event, language).Limit(1).Find(&templates).Error; err != nil {
+		return nil, errors.New("failed to retrieve message template")
+	}
+	if len(templates) == 0 {
+		return nil, nil
+	}
+	return &templates[0], nil
+}
+
+func (r *messageTemplateRepo) SaveTemplate(template domain.MessageTemplate) (*domain.MessageTemplate, error) {
+	err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "event"}, {Name: "language"}},
+		DoUpdates: clause.AssignmentColumns([]string{"subject", "body", "updated_by", "updated_at"}),
+	}).Create(&template).Error
+	if err != nil {
+		return nil, errors.New("failed to save message template")
+	}
+	return r.GetTemplate(template.Event, template.Language)
+}
+
+func (r *messageTemplateRepo) DeleteTemplate(event domain.NotificationEvent, language string) error {
+	if err := r.db.Where("event = ? AND language = ?", event, language).Delete(&domain.MessageTemplate{}).Error; err != nil {
+		return errors.New("failed to delete message template")
+	}
+	return nil
+}
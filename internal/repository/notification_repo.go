@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"butik/internal/domain"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type NotificationRepo interface {
+	CreateNotifications(notifications []domain.Notification) ([]domain.Notification, error)
+	GetDueNotificationIDs(now time.Time, limit int) ([]uint, error)
+	ClaimNotification(id uint, now time.Time, lease time.Duration) (*domain.Notification, error)
+	RecordAttempt(notification *domain.Notification, attempt domain.NotificationAttempt) error
+	RequeueNotification(id uint, now time.Time) (*domain.Notification, error)
+	GetAllNotifications(query domain.NotificationListQuery, offset, limit int) ([]domain.Notification, int, error)
+	GetNotificationByID(id uint) (*domain.Notification, error)
+}
+
+type notificationRepo struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepo(db *gorm.DB) NotificationRepo {
+	return &notificationRepo{db: db}
+}
+
+func (r *notificationRepo) CreateNotifications(notifications []domain.Notification) ([]domain.Notification, error) {
+	if len(notifications) == 0 {
+		return notifications, nil
+	}
+	if err := r.db.Create(&notifications).Error; err != nil {
+		return nil, errors.New("failed to create notifications")
+	}
+	return notifications, nil
+}
+
+func (r *notificationRepo) GetDueNotificationIDs(now time.Time, limit int) ([]uint, error) {
+	var ids []uint
+	err := r.db.Model(&domain.Notification{}).
+		Where("status = ? AND next_attempt_at <= ? AND (locked_until IS NULL OR locked_until < ?)", domain.NotificationStatusPending, now, now).
+		Order("next_attempt_at ASC").
+		Limit(limit).
+		Pluck("id", &ids).Error
+	if err != nil {
+		return nil, errors.New("failed to retrieve due notifications")
+	}
+	return ids, nil
+}
+
+// ClaimNotification kunci notifikasi selama lease supaya tidak dikirim dua kali
+// oleh pengiriman langsung dan job retry. Nil jika sudah diambil proses lain.
+func (r *notificationRepo) ClaimNotification(id uint, now time.Time, lease time.Duration) (*domain.Notification, error) {
+	lockedUntil := now.Add(lease)
+	result := r.db.Model(&domain.Notification{}).
+		Where("id = ? AND status = ? AND next_attempt_at <= ? AND (locked_until IS NULL OR locked_until < ?)", id, domain.NotificationStatusPending, now, now).
+		Update("locked_until", lockedUntil)
+	if result.Error != nil {
+		return nil, errors.New("failed to claim notification")
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+
+	var notification domain.Notification
+	if err := r.db.First(&notification, id).Error; err != nil {
+		return nil, errors.New("notification not found")
+	}
+	return &notification, nil
+}
+
+// RecordAttempt simpan hasil percobaan dan status terbaru notifikasi, lock dilepas
+func (r *notificationRepo) RecordAttempt(notification *domain.Notification, attempt domain.NotificationAttempt) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		attempt.NotificationID = notification.ID
+		if err := tx.Create(&attempt).Error; err != nil {
+			return errors.New("failed to record notification attempt")
+		}
+
+		notification.LockedUntil = nil
+		err := tx.Model(notification).Select("provider", "status", "attempts", "next_attempt_at", "locked_until", "last_error", "sent_at").Updates(notification).Error
+		if err != nil {
+			return errors.New("failed to update notification")
+		}
+		return nil
+	})
+}
+
+// RequeueNotification jadwalkan ulang notifikasi yang belum terkirim untuk segera dikirim
+func (r *notificationRepo) RequeueNotification(id uint, now time.Time) (*domain.Notification, error) {
+	result := r.db.Model(&domain.Notification{}).
+		Where("id = ? AND status <> ?", id, domain.NotificationStatusSent).
+		Updates(map[string]interface{}{
+			"status":          domain.NotificationStatusPending,
+			"next_attempt_at": now,
+			"locked_until":    nil,
+		})
+	if result.Error != nil {
+		return nil, errors.New("failed to requeue notification")
+	}
+	if result.RowsAffected == 0 {
+		if _, err := r.GetNotificationByID(id); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("notification already sent")
+	}
+	return r.GetNotificationByID(id)
+}
+
+func (r *notificationRepo) GetAllNotifications(query domain.NotificationListQuery, offset, limit int) ([]domain.Notification, int, error) {
+	filtered := func() *gorm.DB {
+		db := r.db.Model(&domain.Notification{})
+		if query.OrderID != "" {
+			db = db.Where("order_id = ?", query.OrderID)
+		}
+		if query.Event != "" {
+			db = db.Where("event = ?", query.Event)
+		}
+		if query.Channel != "" {
+			db = db.Where("channel = ?", query.Channel)
+		}
+		if query.Status != "" {
+			db = db.Where("status = ?", query.Status)
+		}
+		return db
+	}
+
+	var total int64
+	if err := filtered().Count(&total).Error; err != nil {
+		return nil, 0, errors.New("failed to count notifications")
+	}
+
+	var notifications []domain.Notification
+	if err := filtered().Order("created_at DESC, id DESC").Offset(offset).Limit(limit).Find(&notifications).Error; err != nil {
+		return nil, 0, errors.New("failed to retrieve notifications")
+	}
+	return notifications, int(total), nil
+}
+
+func (r *notificationRepo) GetNotificationByID(id uint) (*domain.Notification, error) {
+	var notification domain.Notification
+	err := r.db.Preload("AttemptLogs", func(db *gorm.DB) *gorm.DB {
+		return db.Order("attempt ASC")
+	}).First(&notification, id).Error
+	if err != nil {
+		return nil, errors.New("notification not found")
+	}
+	return &notification, nil
+}
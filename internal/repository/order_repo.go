@@ -3,18 +3,28 @@ package repository
 import (
 	"butik/internal/domain"
 	"errors"
+	"sort"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type OrderRepo interface {
-	CreateOrderWithTransaction(order domain.Order, stockUpdates []struct {
-		ProductID uint
-		NewStock  int
-	}) (*domain.Order, error)
-	GetAllOrders(offset, limit int) ([]domain.Order, int, error)
+	CreateOrderWithTransaction(order domain.Order, numberFormat domain.OrderNumberFormat, reservations []domain.StockReservation, voucherUsage *domain.VoucherUsage) (*domain.Order, error)
+	GetAllOrders(filter domain.OrderFilter, offset, limit int) ([]domain.Order, int, error)
+	// StreamOrders panggil fn per batch order sesuai filter supaya export tidak memuat semua order sekaligus
+	StreamOrders(filter domain.OrderFilter, batchSize int, fn func(orders []domain.Order) error) error
 	GetOrderByID(id string) (*domain.Order, error)
 	UpdateOrderStatus(id string, status domain.OrderStatus) (*domain.Order, error)
+	UpdateOrderTracking(id string, airwayBill, trackingStatus string) (*domain.Order, error)
+	MarkOrderCollected(id string, collectedAt time.Time) (*domain.Order, error)
+	// GetStalePendingOrderIDs tanpa order yang masih punya tagihan gateway aktif pada now
+	GetStalePendingOrderIDs(createdBefore, now time.Time, limit int) ([]string, error)
+	// CancelPendingOrder false jika order sudah tidak pending (misalnya baru dikonfirmasi admin)
+	// atau masih punya tagihan gateway aktif, tagihan pending yang sudah lewat ditandai expired
+	CancelPendingOrder(id, reason string, cancelledAt time.Time) (bool, error)
 	DeleteOrder(id string) error
 }
 
@@ -26,23 +36,42 @@ func NewOrderRepo(db *gorm.DB) OrderRepo {
 	return &orderRepo{db: db}
 }
 
-func (r *orderRepo) CreateOrderWithTransaction(order domain.Order, stockUpdates []struct {
-	ProductID uint
-	NewStock  int
-}) (*domain.Order, error) {
+func (r *orderRepo) CreateOrderWithTransaction(order domain.Order, numberFormat domain.OrderNumberFormat, reservations []domain.StockReservation, voucherUsage *domain.VoucherUsage) (*domain.Order, error) {
 	tx := r.db.Begin()
 	if tx.Error != nil {
 		return nil, errors.New("failed to start transaction")
 	}
 
-	// Update stock
-	for _, su := range stockUpdates {
-		result := tx.Model(&domain.Product{}).Where("id = ?", su.ProductID).Update("stock", su.NewStock)
-		if result.Error != nil {
+	// Tahan stok sampai order dikonfirmasi, urut per product supaya urutan lock konsisten antar transaksi
+	sort.Slice(reservations, func(i, j int) bool {
+		return reservations[i].ProductID < reservations[j].ProductID
+	})
+	for i := range reservations {
+		reservations[i].OrderID = order.ID
+		if err := reserveStock(tx, &reservations[i]); err != nil {
 			tx.Rollback()
-			return nil, errors.New("failed to update stock")
+			return nil, err
 		}
 	}
+	// Kunci voucher supaya kuota tidak terlewati saat order bersamaan
+	if voucherUsage != nil {
+		if err := claimVoucher(tx, voucherUsage); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+
+	if err := linkCustomer(tx, &order); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	// Nomor order diambil terakhir supaya lock counter ditahan sesingkat mungkin
+	if err := assignOrderNumber(tx, &order, numberFormat); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
 	// order transaction
 	result := tx.Create(&order)
 	if result.Error != nil {
@@ -50,6 +79,14 @@ func (r *orderRepo) CreateOrderWithTransaction(order domain.Order, stockUpdates
 		return nil, errors.New("failed to create order")
 	}
 
+	if voucherUsage != nil {
+		voucherUsage.OrderID = order.ID
+		if err := tx.Create(voucherUsage).Error; err != nil {
+			tx.Rollback()
+			return nil, errors.New("failed to record voucher usage")
+		}
+	}
+
 	// Commit transaction
 	if err := tx.Commit().Error; err != nil {
 		return nil, errors.New("failed to commit transaction")
@@ -58,46 +95,333 @@ func (r *orderRepo) CreateOrderWithTransaction(order domain.Order, stockUpdates
 	return &order, nil
 }
 
-func (r *orderRepo) GetAllOrders(offset, limit int) ([]domain.Order, int, error) {
+func claimVoucher(tx *gorm.DB, usage *domain.VoucherUsage) error {
+	var voucher domain.Voucher
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&voucher, usage.VoucherID).Error; err != nil {
+		return domain.RejectOrder("voucher not found")
+	}
+
+	if voucher.UsageLimit > 0 && voucher.UsedCount >= voucher.UsageLimit {
+		return domain.RejectOrder("voucher usage limit reached")
+	}
+
+	if voucher.UsageLimitPerWhatsapp > 0 {
+		var used int64
+		if err := tx.Model(&domain.VoucherUsage{}).Where("voucher_id = ? AND whatsapp = ?", voucher.ID, usage.Whatsapp).Count(&used).Error; err != nil {
+			return errors.New("failed to count voucher usage")
+		}
+		if int(used) >= voucher.UsageLimitPerWhatsapp {
+			return domain.RejectOrder("voucher usage limit reached for this whatsapp number")
+		}
+	}
+
+	if err := tx.Model(&voucher).Update("used_count", gorm.Expr("used_count + 1")).Error; err != nil {
+		return errors.New("failed to update voucher usage")
+	}
+	return nil
+}
+
+func (r *orderRepo) GetAllOrders(filter domain.OrderFilter, offset, limit int) ([]domain.Order, int, error) {
 	var orders []domain.Order
 	var total int64
 
-	if err := r.db.Model(&domain.Order{}).Count(&total).Error; err != nil {
+	if err := r.filteredOrders(filter).Count(&total).Error; err != nil {
 		return nil, 0, errors.New("failed to count orders")
 	}
 
-	if err := r.db.Preload("OrderItems.Product.Category").Order("created_at DESC").Offset(offset).Limit(limit).Find(&orders).Error; err != nil {
+	if err := r.filteredOrders(filter).Preload("OrderItems.Product.Category").Preload("Discounts").Order(orderSortClause(filter)).Offset(offset).Limit(limit).Find(&orders).Error; err != nil {
 		return nil, 0, errors.New("failed to retrieve orders")
 	}
 	return orders, int(total), nil
 }
 
+// StreamOrders pakai keyset (kolom sort, id) dari order terakhir, bukan OFFSET, supaya order baru
+// selama export tidak menggeser batch dan batch akhir tetap murah
+func (r *orderRepo) StreamOrders(filter domain.OrderFilter, batchSize int, fn func(orders []domain.Order) error) error {
+	column, direction := orderSort(filter)
+	comparison := "<"
+	if direction == "ASC" {
+		comparison = ">"
+	}
+
+	var last *domain.Order
+	for {
+		query := r.filteredOrders(filter)
+		if last != nil {
+			query = query.Where("("+column+", id) "+comparison+" (?, ?)", orderSortValue(last, column), last.ID)
+		}
+
+		var orders []domain.Order
+		if err := query.Preload("OrderItems.Product").Preload("Discounts").Order(orderSortClause(filter)).Limit(batchSize).Find(&orders).Error; err != nil {
+			return errors.New("failed to retrieve orders")
+		}
+		if len(orders) == 0 {
+			return nil
+		}
+		if err := fn(orders); err != nil {
+			return err
+		}
+		if len(orders) < batchSize {
+			return nil
+		}
+		last = &orders[len(orders)-1]
+	}
+}
+
+// filteredOrders query order sesuai filter admin
+func (r *orderRepo) filteredOrders(filter domain.OrderFilter) *gorm.DB {
+	query := r.db.Model(&domain.Order{})
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.CreatedFrom != nil {
+		query = query.Where("created_at >= ?", *filter.CreatedFrom)
+	}
+	if filter.CreatedBefore != nil {
+		query = query.Where("created_at < ?", *filter.CreatedBefore)
+	}
+	if filter.CustomerName != "" {
+		query = query.Where("customer_name ILIKE ?", "%"+escapeLike(filter.CustomerName)+"%")
+	}
+	if filter.Whatsapp != "" {
+		query = query.Where("whatsapp LIKE ?", "%"+escapeLike(filter.Whatsapp)+"%")
+	}
+	if filter.IDPrefix != "" {
+		query = query.Where("id LIKE ?", escapeLike(filter.IDPrefix)+"%")
+	}
+	if filter.NumberPrefix != "" {
+		query = query.Where("order_number LIKE ?", escapeLike(filter.NumberPrefix)+"%")
+	}
+	if filter.MinTotal > 0 {
+		query = query.Where("total_price >= ?", filter.MinTotal)
+	}
+	if filter.MaxTotal > 0 {
+		query = query.Where("total_price <= ?", filter.MaxTotal)
+	}
+	if filter.ProductID != 0 {
+		query = query.Where("EXISTS (SELECT 1 FROM order_items WHERE order_items.order_id = orders.id AND order_items.product_id = ?)", filter.ProductID)
+	}
+	if filter.CustomerID != 0 {
+		query = query.Where("customer_id = ?", filter.CustomerID)
+	}
+	return query
+}
+
+func orderSortClause(filter domain.OrderFilter) string {
+	column, direction := orderSort(filter)
+	return column + " " + direction + ", id " + direction
+}
+
+// orderSort kolom dan arah sort dari filter, hanya kolom yang diizinkan
+func orderSort(filter domain.OrderFilter) (string, string) {
+	column := "created_at"
+	switch filter.SortBy {
+	case "total_price", "customer_name":
+		column = filter.SortBy
+	}
+
+	direction := "DESC"
+	if filter.SortOrder == "asc" {
+		direction = "ASC"
+	}
+	return column, direction
+}
+
+// orderSortValue nilai kolom sort order untuk keyset
+func orderSortValue(order *domain.Order, column string) interface{} {
+	switch column {
+	case "total_price":
+		return order.TotalPrice
+	case "customer_name":
+		return order.CustomerName
+	}
+	return order.CreatedAt
+}
+
+// escapeLike supaya % dan _ dari input dicari apa adanya
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
+
 func (r *orderRepo) GetOrderByID(id string) (*domain.Order, error) {
 	order := &domain.Order{}
-	result := r.db.Preload("OrderItems.Product.Category").First(order, "id = ?", id)
+	result := r.db.Preload("OrderItems.Product.Category").Preload("Discounts").Preload("Payments", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id ASC")
+	}).First(order, "id = ?", id)
 	if result.Error != nil {
-		return nil, errors.New("order not found")
+		return nil, domain.ErrOrderNotFound
 	}
 	return order, nil
 }
 
 func (r *orderRepo) UpdateOrderStatus(id string, status domain.OrderStatus) (*domain.Order, error) {
-	order, err := r.GetOrderByID(id)
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var order domain.Order
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&order, "id = ?", id).Error; err != nil {
+			return domain.ErrOrderNotFound
+		}
+
+		// Reservasi stok mengikuti status order
+		switch status {
+		case domain.OrderStatusSuccess:
+			if err := convertReservations(tx, id); err != nil {
+				return err
+			}
+		case domain.OrderStatusRejected:
+			if err := releaseReservations(tx, id); err != nil {
+				return err
+			}
+			if err := releaseVoucherUsage(tx, id); err != nil {
+				return err
+			}
+		}
+
+		updates := map[string]interface{}{"status": status}
+		// Waktu bayar dicatat saat pertama kali diverifikasi
+		if status == domain.OrderStatusSuccess && order.PaidAt == nil {
+			updates["paid_at"] = time.Now()
+		}
+		if err := tx.Model(&order).Updates(updates).Error; err != nil {
+			return errors.New("failed to update order status")
+		}
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	order.Status = status
-	result := r.db.Save(order)
+	return r.GetOrderByID(id)
+}
+
+func (r *orderRepo) UpdateOrderTracking(id string, airwayBill, trackingStatus string) (*domain.Order, error) {
+	result := r.db.Model(&domain.Order{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"airway_bill":     airwayBill,
+		"tracking_status": trackingStatus,
+	})
 	if result.Error != nil {
-		return nil, errors.New("failed to update order status")
+		return nil, errors.New("failed to update order tracking")
 	}
-	return order, nil
+	return r.GetOrderByID(id)
 }
 
-func (r *orderRepo) DeleteOrder(id string) error {
-	result := r.db.Delete(&domain.Order{}, "id = ?", id)
+// MarkOrderCollected hanya berhasil sekali, scan ulang kode yang sama akan ditolak
+func (r *orderRepo) MarkOrderCollected(id string, collectedAt time.Time) (*domain.Order, error) {
+	result := r.db.Model(&domain.Order{}).Where("id = ? AND collected_at IS NULL", id).Update("collected_at", collectedAt)
 	if result.Error != nil {
-		return errors.New("failed to delete order")
+		return nil, errors.New("failed to mark order as collected")
+	}
+	if result.RowsAffected == 0 {
+		return nil, errors.New("order has already been collected")
+	}
+	return r.GetOrderByID(id)
+}
+
+func (r *orderRepo) GetStalePendingOrderIDs(createdBefore, now time.Time, limit int) ([]string, error) {
+	var ids []string
+	if err := r.db.Model(&domain.Order{}).
+		Where("status = ? AND created_at < ?", domain.OrderStatusPending, createdBefore).
+		Where("NOT EXISTS (SELECT 1 FROM payments WHERE payments.order_id = orders.id AND payments.status = ? AND (payments.expires_at IS NULL OR payments.expires_at > ?))", domain.PaymentStatusPending, now).
+		Order("created_at ASC").Limit(limit).Pluck("id", &ids).Error; err != nil {
+		return nil, errors.New("failed to retrieve pending orders")
+	}
+	return ids, nil
+}
+
+func (r *orderRepo) CancelPendingOrder(id, reason string, cancelledAt time.Time) (bool, error) {
+	cancelled := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var order domain.Order
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&order, "id = ?", id).Error; err != nil {
+			return domain.ErrOrderNotFound
+		}
+		if order.Status != domain.OrderStatusPending {
+			return nil
+		}
+
+		// Customer masih bisa membayar tagihan yang belum lewat, order jangan dibatalkan dulu
+		var openPayments int64
+		if err := tx.Model(&domain.Payment{}).
+			Where("order_id = ? AND status = ? AND (expires_at IS NULL OR expires_at > ?)", id, domain.PaymentStatusPending, cancelledAt).
+			Count(&openPayments).Error; err != nil {
+			return errors.New("failed to retrieve payments")
+		}
+		if openPayments > 0 {
+			return nil
+		}
+		if err := tx.Model(&domain.Payment{}).
+			Where("order_id = ? AND status = ?", id, domain.PaymentStatusPending).
+			Update("status", domain.PaymentStatusExpired).Error; err != nil {
+			return errors.New("failed to expire payments")
+		}
+
+		// Kembalikan stok dan kuota voucher
+		if err := releaseReservations(tx, id); err != nil {
+			return err
+		}
+		if err := releaseVoucherUsage(tx, id); err != nil {
+			return err
+		}
+
+		if err := tx.Model(&order).Updates(map[string]interface{}{
+			"status":        domain.OrderStatusCancelled,
+			"cancel_reason": reason,
+			"cancelled_at":  cancelledAt,
+		}).Error; err != nil {
+			return errors.New("failed to cancel order")
+		}
+		cancelled = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return cancelled, nil
+}
+
+func releaseVoucherUsage(tx *gorm.DB, orderID string) error {
+	var usages []domain.VoucherUsage
+	if err := tx.Where("order_id = ?", orderID).Find(&usages).Error; err != nil {
+		return errors.New("failed to retrieve voucher usage")
+	}
+
+	for _, usage := range usages {
+		if err := tx.Model(&domain.Voucher{}).Where("id = ? AND used_count > 0", usage.VoucherID).
+			Update("used_count", gorm.Expr("used_count - 1")).Error; err != nil {
+			return errors.New("failed to update voucher usage")
+		}
+		if err := tx.Delete(&usage).Error; err != nil {
+			return errors.New("failed to release voucher usage")
+		}
 	}
 	return nil
 }
+
+// DeleteOrder lepas stok yang masih ditahan dan kuota voucher order sebelum dihapus,
+// penjualan yang sudah tercatat di ledger tidak dikembalikan
+func (r *orderRepo) DeleteOrder(id string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var order domain.Order
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&order, "id = ?", id).Error; err != nil {
+			return domain.ErrOrderNotFound
+		}
+
+		var reservations []domain.StockReservation
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("order_id = ? AND status = ?", id, domain.StockReservationActive).
+			Find(&reservations).Error; err != nil {
+			return errors.New("failed to retrieve stock reservations")
+		}
+		for i := range reservations {
+			if err := releaseReservation(tx, &reservations[i]); err != nil {
+				return err
+			}
+		}
+		if err := releaseVoucherUsage(tx, id); err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&order).Error; err != nil {
+			return errors.New("failed to delete order")
+		}
+		return nil
+	})
+}
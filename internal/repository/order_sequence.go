@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"butik/internal/domain"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// assignOrderNumber ambil nomor berikutnya di dalam transaksi order.
+// Upsert mengunci baris periode sampai commit, jadi order bersamaan antre
+// dan nomor ikut rollback jika order gagal dibuat (tanpa celah).
+func assignOrderNumber(tx *gorm.DB, order *domain.Order, format domain.OrderNumberFormat) error {
+	period := format.Period(order.CreatedAt)
+
+	var seq int
+	err := tx.Raw(`INSERT INTO order_sequences (period, last_number, updated_at) VALUES (?, 1, NOW())
+		ON CONFLICT (period) DO UPDATE SET last_number = order_sequences.last_number + 1, updated_at = NOW()
+		RETURNING last_number`, period).Scan(&seq).Error
+	if err != nil || seq == 0 {
+		return errors.New("failed to generate order number")
+	}
+
+	order.OrderNumber = format.Format(period, seq)
+	return nil
+}
@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"butik/internal/domain"
+	"errors"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PaymentRepo interface {
+	CreatePayment(payment domain.Payment) (*domain.Payment, error)
+	GetPaymentByReference(reference string) (*domain.Payment, error)
+	// GetOpenPayment tagihan pending order yang belum kadaluarsa, nil jika tidak ada
+	GetOpenPayment(orderID string, now time.Time) (*domain.Payment, error)
+	GetPendingPayments(createdBefore time.Time, limit int) ([]domain.Payment, error)
+	// UpdatePendingPayment ubah status tagihan yang masih pending, false jika sudah berubah
+	UpdatePendingPayment(id uint, status domain.PaymentStatus) (bool, error)
+	// SettlePayment tandai tagihan paid dan order pending menjadi success dalam satu transaksi
+	SettlePayment(id uint, paidAt time.Time) (settled bool, confirmed bool, err error)
+}
+
+type paymentRepo struct {
+	db *gorm.DB
+}
+
+func NewPaymentRepo(db *gorm.DB) PaymentRepo {
+	return &paymentRepo{db: db}
+}
+
+func (r *paymentRepo) CreatePayment(payment domain.Payment) (*domain.Payment, error) {
+	if err := r.db.Create(&payment).Error; err != nil {
+		return nil, domain.ErrPaymentFailed
+	}
+	return &payment, nil
+}
+
+func (r *paymentRepo) GetPaymentByReference(reference string) (*domain.Payment, error) {
+	var payment domain.Payment
+	if err := r.db.Where("reference = ?", reference).First(&payment).Error; err != nil {
+		return nil, domain.ErrPaymentNotFound
+	}
+	return &payment, nil
+}
+
+func (r *paymentRepo) GetOpenPayment(orderID string, now time.Time) (*domain.Payment, error) {
+	var payments []domain.Payment
+	err := r.db.Where("order_id = ? AND status = ? AND (expires_at IS NULL OR expires_at > ?)", orderID, domain.PaymentStatusPending, now).
+		Order("id DESC").Limit(1).Find(&payments).Error
+	if err != nil {
+		return nil, errors.New("failed to retrieve payment")
+	}
+	if len(payments) == 0 {
+		return nil, nil
+	}
+	return &payments[0], nil
+}
+
+func (r *paymentRepo) GetPendingPayments(createdBefore time.Time, limit int) ([]domain.Payment, error) {
+	var payments []domain.Payment
+	if err := r.db.Where("status = ? AND created_at < ?", domain.PaymentStatusPending, createdBefore).
+		Order("created_at ASC").Limit(limit).Find(&payments).Error; err != nil {
+		return nil, errors.New("failed to retrieve pending payments")
+	}
+	return payments, nil
+}
+
+func (r *paymentRepo) UpdatePendingPayment(id uint, status domain.PaymentStatus) (bool, error) {
+	result := r.db.Model(&domain.Payment{}).Where("id = ? AND status = ?", id, domain.PaymentStatusPending).Update("status", status)
+	if result.Error != nil {
+		return false, errors.New("failed to update payment")
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// SettlePayment callback yang sama bisa datang berkali-kali, hanya yang pertama yang diproses.
+// Pembayaran tetap dicatat paid walaupun order tidak bisa dikonfirmasi (sudah dibatalkan
+// atau stok habis), confirmed false supaya admin bisa menindaklanjuti.
+func (r *paymentRepo) SettlePayment(id uint, paidAt time.Time) (bool, bool, error) {
+	settled, confirmed := false, false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var payment domain.Payment
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&payment, id).Error; err != nil {
+			return domain.ErrPaymentNotFound
+		}
+		if payment.Status == domain.PaymentStatusPaid {
+			return nil
+		}
+
+		if err := tx.Model(&payment).Updates(map[string]interface{}{
+			"status":  domain.PaymentStatusPaid,
+			"paid_at": paidAt,
+		}).Error; err != nil {
+			return errors.New("failed to update payment")
+		}
+		settled = true
+
+		var order domain.Order
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&order, "id = ?", payment.OrderID).Error; err != nil {
+			return domain.ErrOrderNotFound
+		}
+		if order.Status != domain.OrderStatusPending {
+			return nil
+		}
+
+		// Savepoint, gagal konfirmasi order tidak membatalkan catatan pembayaran
+		err := tx.Transaction(func(tx *gorm.DB) error {
+			if err := convertReservations(tx, order.ID); err != nil {
+				return err
+			}
+			return tx.Model(&order).Updates(map[string]interface{}{
+				"status":  domain.OrderStatusSuccess,
+				"paid_at": paidAt,
+			}).Error
+		})
+		if err != nil {
+			log.Printf("Failed to confirm paid order %s: %v", order.ID, err)
+			return nil
+		}
+		confirmed = true
+		return nil
+	})
+	if err != nil {
+		return false, false, err
+	}
+	return settled, confirmed, nil
+}
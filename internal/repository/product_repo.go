@@ -5,12 +5,16 @@ import (
 	"errors"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ProductRepo interface {
-	CreateProduct(domain.Product) (*domain.Product, error)
+	CreateProduct(product domain.Product, userID *uint) (*domain.Product, error)
 	GetAllProducts(offset, limit int) ([]domain.Product, int, error)
 	GetProductByID(id uint) (*domain.Product, error)
+	// GetLowStockProducts product dengan stok tersedia <= threshold, stok tersedia terkecil dulu
+	GetLowStockProducts(defaultThreshold, offset, limit int) ([]domain.Product, int, error)
+	// UpdateProduct ubah data product, Stock dan Reserved diabaikan
 	UpdateProduct(id uint, product domain.Product) (*domain.Product, error)
 	DeleteProduct(id uint) error
 }
@@ -23,11 +27,31 @@ func NewProductRepo(db *gorm.DB) ProductRepo {
 	return &productRepo{db: db}
 }
 
-func (r *productRepo) CreateProduct(product domain.Product) (*domain.Product, error) {
-	result := r.db.Create(&product)
-	if result.Error != nil {
-		return nil, errors.New("Failed to create product")
+func (r *productRepo) CreateProduct(product domain.Product, userID *uint) (*domain.Product, error) {
+	// Stok awal masuk lewat ledger
+	initialStock := product.Stock
+	product.Stock = 0
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&product).Error; err != nil {
+			return errors.New("Failed to create product")
+		}
+		if initialStock == 0 {
+			return nil
+		}
+		return applyStockMovement(tx, &domain.StockMovement{
+			ProductID: product.ID,
+			Delta:     initialStock,
+			Reason:    domain.StockMovementRestock,
+			UserID:    userID,
+			Note:      "initial stock",
+		})
+	})
+	if err != nil {
+		return nil, err
 	}
+
+	product.Stock = initialStock
 	return &product, nil
 }
 
@@ -55,23 +79,44 @@ func (r *productRepo) GetProductByID(id uint) (*domain.Product, error) {
 	return product, nil
 }
 
+func (r *productRepo) GetLowStockProducts(defaultThreshold, offset, limit int) ([]domain.Product, int, error) {
+	var products []domain.Product
+	var total int64
+
+	query := r.db.Model(&domain.Product{}).
+		Where("stock - reserved <= CASE WHEN low_stock_threshold > 0 THEN low_stock_threshold ELSE ? END", defaultThreshold)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.New("Failed to count products")
+	}
+
+	if err := query.Preload("Category").Order("stock - reserved ASC, id ASC").Offset(offset).Limit(limit).Find(&products).Error; err != nil {
+		return nil, 0, errors.New("Failed to retrieve products")
+	}
+	return products, int(total), nil
+}
+
 func (r *productRepo) UpdateProduct(id uint, updatedProduct domain.Product) (*domain.Product, error) {
-	product, err := r.GetProductByID(id)
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		product := &domain.Product{}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(product, id).Error; err != nil {
+			return errors.New("Product not found")
+		}
+
+		product.Name = updatedProduct.Name
+		product.Price = updatedProduct.Price
+		product.Description = updatedProduct.Description
+		product.Weight = updatedProduct.Weight
+		product.LowStockThreshold = updatedProduct.LowStockThreshold
+		product.CategoryID = updatedProduct.CategoryID
+		product.ImageURL = updatedProduct.ImageURL
+
+		// Stock dan Reserved hanya berubah lewat ledger dan reservasi order
+		return tx.Omit("Stock", "Reserved", "Category").Save(product).Error
+	})
 	if err != nil {
-		return nil, errors.New("Product not found")
+		return nil, err
 	}
-	product.Name = updatedProduct.Name
-	product.Price = updatedProduct.Price
-	product.Description = updatedProduct.Description
-	product.Stock = updatedProduct.Stock
-	product.CategoryID = updatedProduct.CategoryID
-	product.ImageURL = updatedProduct.ImageURL
-
-	result := r.db.Save(product)
-	if result.Error != nil {
-		return nil, result.Error
-	}
-	return product, nil
+	return r.GetProductByID(id)
 }
 
 func (r *productRepo) DeleteProduct(id uint) error {
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"butik/internal/domain"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type PromotionRepo interface {
+	CreatePromotion(promotion domain.Promotion) (*domain.Promotion, error)
+	GetAllPromotions(offset, limit int) ([]domain.Promotion, int, error)
+	GetActivePromotions(now time.Time) ([]domain.Promotion, error)
+	GetPromotionByID(id uint) (*domain.Promotion, error)
+	UpdatePromotion(id uint, promotion domain.Promotion) (*domain.Promotion, error)
+	DeletePromotion(id uint) error
+}
+
+type promotionRepo struct {
+	db *gorm.DB
+}
+
+func NewPromotionRepo(db *gorm.DB) PromotionRepo {
+	return &promotionRepo{db: db}
+}
+
+func (r *promotionRepo) CreatePromotion(promotion domain.Promotion) (*domain.Promotion, error) {
+	result := r.db.Create(&promotion)
+	if result.Error != nil {
+		return nil, errors.New("failed to create promotion")
+	}
+	return &promotion, nil
+}
+
+func (r *promotionRepo) GetAllPromotions(offset, limit int) ([]domain.Promotion, int, error) {
+	var promotions []domain.Promotion
+	var total int64
+
+	if err := r.db.Model(&domain.Promotion{}).Count(&total).Error; err != nil {
+		return nil, 0, errors.New("failed to count promotions")
+	}
+
+	if err := r.db.Preload("Categories").Preload("Products").Order("priority DESC, created_at DESC").Offset(offset).Limit(limit).Find(&promotions).Error; err != nil {
+		return nil, 0, errors.New("failed to retrieve promotions")
+	}
+
+	return promotions, int(total), nil
+}
+
+func (r *promotionRepo) GetActivePromotions(now time.Time) ([]domain.Promotion, error) {
+	var promotions []domain.Promotion
+	err := r.db.Preload("Categories").Preload("Products").
+		Where("is_active = ?", true).
+		Where("starts_at IS NULL OR starts_at <= ?", now).
+		Where("ends_at IS NULL OR ends_at >= ?", now).
+		Order("priority DESC, id ASC").
+		Find(&promotions).Error
+	if err != nil {
+		return nil, errors.New("failed to retrieve active promotions")
+	}
+	return promotions, nil
+}
+
+func (r *promotionRepo) GetPromotionByID(id uint) (*domain.Promotion, error) {
+	promotion := &domain.Promotion{}
+	result := r.db.Preload("Categories").Preload("Products").First(promotion, id)
+	if result.Error != nil {
+		return nil, errors.New("promotion not found")
+	}
+	return promotion, nil
+}
+
+func (r *promotionRepo) UpdatePromotion(id uint, updatedPromotion domain.Promotion) (*domain.Promotion, error) {
+	promotion, err := r.GetPromotionByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedPromotion.ID = promotion.ID
+	updatedPromotion.CreatedAt = promotion.CreatedAt
+
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&updatedPromotion).Select("*").Omit("ID", "CreatedAt", "Categories", "Products").Updates(&updatedPromotion).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&updatedPromotion).Association("Categories").Replace(updatedPromotion.Categories); err != nil {
+			return err
+		}
+		return tx.Model(&updatedPromotion).Association("Products").Replace(updatedPromotion.Products)
+	})
+	if err != nil {
+		return nil, errors.New("failed to update promotion")
+	}
+
+	return r.GetPromotionByID(id)
+}
+
+func (r *promotionRepo) DeletePromotion(id uint) error {
+	promotion, err := r.GetPromotionByID(id)
+	if err != nil {
+		return err
+	}
+	result := r.db.Select("Categories", "Products").Delete(promotion)
+	if result.Error != nil {
+		return errors.New("failed to delete promotion")
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"butik/internal/domain"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ReportRepo agregasi order untuk dashboard admin.
+// Rentang waktu [from, to), timezone dipakai untuk mengelompokkan tanggal sesuai jam toko.
+type ReportRepo interface {
+	GetSalesByPeriod(from, to time.Time, groupBy, timezone string) ([]domain.SalesPeriodRow, error)
+	GetTopProducts(from, to time.Time, sortBy string, limit int) ([]domain.TopProductRow, error)
+	GetTopCategories(from, to time.Time, sortBy string, limit int) ([]domain.TopCategoryRow, error)
+	GetStatusBreakdown(from, to time.Time) ([]domain.StatusBreakdownRow, error)
+}
+
+type reportRepo struct {
+	db *gorm.DB
+}
+
+func NewReportRepo(db *gorm.DB) ReportRepo {
+	return &reportRepo{db: db}
+}
+
+func (r *reportRepo) GetSalesByPeriod(from, to time.Time, groupBy, timezone string) ([]domain.SalesPeriodRow, error) {
+	var rows []domain.SalesPeriodRow
+	err := r.db.Model(&domain.Order{}).
+		Select("to_char(date_trunc(?, created_at AT TIME ZONE ?), 'YYYY-MM-DD') AS period, COUNT(*) AS order_count, COALESCE(SUM(total_price), 0) AS revenue", groupBy, timezone).
+		Where("status = ? AND created_at >= ? AND created_at < ?", domain.OrderStatusSuccess, from, to).
+		Group("period").
+		Order("period ASC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, errors.New("failed to retrieve sales report")
+	}
+	return rows, nil
+}
+
+func (r *reportRepo) GetTopProducts(from, to time.Time, sortBy string, limit int) ([]domain.TopProductRow, error) {
+	var rows []domain.TopProductRow
+	err := r.soldItems(from, to).
+		Select("order_items.product_id, COALESCE(products.name, '') AS name, SUM(order_items.quantity) AS units, SUM(order_items.price_at_purchase * order_items.quantity) AS revenue").
+		Joins("LEFT JOIN products ON products.id = order_items.product_id").
+		Group("order_items.product_id, products.name").
+		Order(sortBy + " DESC").
+		Limit(limit).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, errors.New("failed to retrieve top products")
+	}
+	return rows, nil
+}
+
+func (r *reportRepo) GetTopCategories(from, to time.Time, sortBy string, limit int) ([]domain.TopCategoryRow, error) {
+	var rows []domain.TopCategoryRow
+	err := r.soldItems(from, to).
+		Select("categories.id AS category_id, categories.name, SUM(order_items.quantity) AS units, SUM(order_items.price_at_purchase * order_items.quantity) AS revenue").
+		Joins("JOIN products ON products.id = order_items.product_id").
+		Joins("JOIN categories ON categories.id = products.category_id").
+		Group("categories.id, categories.name").
+		Order(sortBy + " DESC").
+		Limit(limit).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, errors.New("failed to retrieve top categories")
+	}
+	return rows, nil
+}
+
+func (r *reportRepo) GetStatusBreakdown(from, to time.Time) ([]domain.StatusBreakdownRow, error) {
+	var rows []domain.StatusBreakdownRow
+	err := r.db.Model(&domain.Order{}).
+		Select("status, COUNT(*) AS order_count, COALESCE(SUM(total_price), 0) AS total").
+		Where("created_at >= ? AND created_at < ?", from, to).
+		Group("status").
+		Order("order_count DESC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, errors.New("failed to retrieve status breakdown")
+	}
+	return rows, nil
+}
+
+// soldItems item dari order sukses dalam rentang waktu
+func (r *reportRepo) soldItems(from, to time.Time) *gorm.DB {
+	return r.db.Table("order_items").
+		Joins("JOIN orders ON orders.id = order_items.order_id").
+		Where("orders.status = ? AND orders.created_at >= ? AND orders.created_at < ?", domain.OrderStatusSuccess, from, to)
+}
@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"butik/internal/domain"
+	"errors"
+	"fmt"
+	"sort"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type StockMovementRepo interface {
+	RecordMovement(movement domain.StockMovement) (*domain.StockMovement, error)
+	// RecordMovements semua movement berhasil atau tidak sama sekali
+	RecordMovements(movements []domain.StockMovement) ([]domain.StockMovement, error)
+	GetMovementsByProductID(productID uint, offset, limit int) ([]domain.StockMovement, int, error)
+	GetLedgerStock(productID uint) (int, error)
+	// SeedOpeningBalances catat stok product tanpa movement sebagai saldo awal, mengembalikan jumlah product
+	SeedOpeningBalances() (int, error)
+	// GetStockDrifts product yang stoknya tidak sama dengan total ledger
+	GetStockDrifts() ([]domain.StockDrift, error)
+}
+
+type stockMovementRepo struct {
+	db *gorm.DB
+}
+
+func NewStockMovementRepo(db *gorm.DB) StockMovementRepo {
+	return &stockMovementRepo{db: db}
+}
+
+func (r *stockMovementRepo) RecordMovement(movement domain.StockMovement) (*domain.StockMovement, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		return applyStockMovement(tx, &movement)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &movement, nil
+}
+
+func (r *stockMovementRepo) RecordMovements(movements []domain.StockMovement) ([]domain.StockMovement, error) {
+	// Urutkan per product supaya urutan lock konsisten antar transaksi
+	sort.Slice(movements, func(i, j int) bool {
+		return movements[i].ProductID < movements[j].ProductID
+	})
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		for i := range movements {
+			if err := applyStockMovement(tx, &movements[i]); err != nil {
+				return fmt.Errorf("product %d: %w", movements[i].ProductID, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return movements, nil
+}
+
+func (r *stockMovementRepo) GetMovementsByProductID(productID uint, offset, limit int) ([]domain.StockMovement, int, error) {
+	var movements []domain.StockMovement
+	var total int64
+
+	query := r.db.Model(&domain.StockMovement{}).Where("product_id = ?", productID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.New("failed to count stock movements")
+	}
+
+	if err := query.Order("created_at DESC, id DESC").Offset(offset).Limit(limit).Find(&movements).Error; err != nil {
+		return nil, 0, errors.New("failed to retrieve stock movements")
+	}
+	return movements, int(total), nil
+}
+
+func (r *stockMovementRepo) GetLedgerStock(productID uint) (int, error) {
+	var stock int
+	if err := r.db.Model(&domain.StockMovement{}).Select("COALESCE(SUM(delta), 0)").Where("product_id = ?", productID).Scan(&stock).Error; err != nil {
+		return 0, errors.New("failed to calculate ledger stock")
+	}
+	return stock, nil
+}
+
+// SeedOpeningBalances dijalankan lewat migrasi sebelum API melayani order,
+// stok product yang belum punya movement dicatat sebagai saldo awal
+func (r *stockMovementRepo) SeedOpeningBalances() (int, error) {
+	var productIDs []uint
+	if err := r.db.Model(&domain.Product{}).
+		Where("stock <> 0 AND NOT EXISTS (SELECT 1 FROM stock_movements WHERE stock_movements.product_id = products.id)").
+		Order("id ASC").Pluck("id", &productIDs).Error; err != nil {
+		return 0, errors.New("failed to retrieve products")
+	}
+
+	var seeded int
+	for _, productID := range productIDs {
+		created := false
+		err := r.db.Transaction(func(tx *gorm.DB) error {
+			var product domain.Product
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&product, productID).Error; err != nil {
+				return errors.New("product not found")
+			}
+
+			// Cek ulang setelah lock, movement bisa saja baru dicatat
+			var count int64
+			if err := tx.Model(&domain.StockMovement{}).Where("product_id = ?", productID).Count(&count).Error; err != nil {
+				return errors.New("failed to count stock movements")
+			}
+			if count > 0 || product.Stock == 0 {
+				return nil
+			}
+
+			if err := tx.Create(&domain.StockMovement{
+				ProductID:  productID,
+				Delta:      product.Stock,
+				StockAfter: product.Stock,
+				Reason:     domain.StockMovementAdjustment,
+				Note:       "opening balance",
+			}).Error; err != nil {
+				return errors.New("failed to record stock movement")
+			}
+			created = true
+			return nil
+		})
+		if err != nil {
+			return seeded, err
+		}
+		if created {
+			seeded++
+		}
+	}
+	return seeded, nil
+}
+
+func (r *stockMovementRepo) GetStockDrifts() ([]domain.StockDrift, error) {
+	var drifts []domain.StockDrift
+	err := r.db.Table("products").
+		Select("products.id AS product_id, products.name, products.stock, COALESCE(ledger.total, 0) AS ledger_stock").
+		Joins("LEFT JOIN (SELECT product_id, SUM(delta) AS total FROM stock_movements GROUP BY product_id) AS ledger ON ledger.product_id = products.id").
+		Where("products.stock <> COALESCE(ledger.total, 0)").
+		Order("products.id ASC").
+		Scan(&drifts).Error
+	if err != nil {
+		return nil, errors.New("failed to compare stock with ledger")
+	}
+	return drifts, nil
+}
+
+// applyStockMovement ubah stok product sebesar delta lalu catat ke ledger dalam transaksi yang sama.
+// Pengurangan ditolak jika stok menjadi kurang dari stok yang ditahan reservasi.
+func applyStockMovement(tx *gorm.DB, movement *domain.StockMovement) error {
+	query := tx.Model(&domain.Product{}).Where("id = ?", movement.ProductID)
+	if movement.Delta < 0 {
+		query = query.Where("stock + ? >= reserved", movement.Delta)
+	}
+
+	result := query.Update("stock", gorm.Expr("stock + ?", movement.Delta))
+	if result.Error != nil {
+		return errors.New("failed to update stock")
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("stock not enough for product")
+	}
+
+	if err := tx.Model(&domain.Product{}).Select("stock").Where("id = ?", movement.ProductID).Scan(&movement.StockAfter).Error; err != nil {
+		return errors.New("failed to read stock")
+	}
+	if err := tx.Create(movement).Error; err != nil {
+		return errors.New("failed to record stock movement")
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"butik/internal/domain"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type StockReservationRepo interface {
+	GetReservationsByOrderID(orderID string) ([]domain.StockReservation, error)
+	ReleaseExpiredReservations(now time.Time) (int, error)
+}
+
+type stockReservationRepo struct {
+	db *gorm.DB
+}
+
+func NewStockReservationRepo(db *gorm.DB) StockReservationRepo {
+	return &stockReservationRepo{db: db}
+}
+
+func (r *stockReservationRepo) GetReservationsByOrderID(orderID string) ([]domain.StockReservation, error) {
+	var reservations []domain.StockReservation
+	if err := r.db.Where("order_id = ?", orderID).Order("id ASC").Find(&reservations).Error; err != nil {
+		return nil, errors.New("failed to retrieve stock reservations")
+	}
+	return reservations, nil
+}
+
+// ReleaseExpiredReservations kembalikan stok dari reservasi aktif yang sudah lewat expires_at
+func (r *stockReservationRepo) ReleaseExpiredReservations(now time.Time) (int, error) {
+	var released int
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var reservations []domain.StockReservation
+		// SKIP LOCKED supaya tidak menunggu reservasi yang sedang dikonfirmasi admin
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND expires_at <= ?", domain.StockReservationActive, now).
+			Find(&reservations).Error; err != nil {
+			return errors.New("failed to retrieve expired stock reservations")
+		}
+
+		for i := range reservations {
+			if err := releaseReservation(tx, &reservations[i]); err != nil {
+				return err
+			}
+		}
+		released = len(reservations)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return released, nil
+}
+
+// reserveStock tahan stok hanya jika stok tersedia (stock - reserved) mencukupi
+func reserveStock(tx *gorm.DB, reservation *domain.StockReservation) error {
+	result := tx.Model(&domain.Product{}).
+		Where("id = ? AND stock - reserved >= ?", reservation.ProductID, reservation.Quantity).
+		Update("reserved", gorm.Expr("reserved + ?", reservation.Quantity))
+	if result.Error != nil {
+		return errors.New("failed to reserve stock")
+	}
+	if result.RowsAffected == 0 {
+		return domain.RejectOrder("stock not enough for product")
+	}
+	if err := tx.Create(reservation).Error; err != nil {
+		return errors.New("failed to create stock reservation")
+	}
+	return nil
+}
+
+// convertReservations ubah reservasi order menjadi penjualan saat order dikonfirmasi.
+// Reservasi yang sudah dilepas (kadaluarsa/ditolak) harus mengambil stok lagi.
+func convertReservations(tx *gorm.DB, orderID string) error {
+	var reservations []domain.StockReservation
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("order_id = ? AND status IN ?", orderID, []domain.StockReservationStatus{domain.StockReservationActive, domain.StockReservationReleased}).
+		Find(&reservations).Error; err != nil {
+		return errors.New("failed to retrieve stock reservations")
+	}
+
+	for _, reservation := range reservations {
+		if reservation.Status == domain.StockReservationActive {
+			if err := tx.Model(&domain.Product{}).Where("id = ?", reservation.ProductID).
+				Update("reserved", gorm.Expr("reserved - ?", reservation.Quantity)).Error; err != nil {
+				return errors.New("failed to update reserved stock")
+			}
+		}
+
+		if err := applyStockMovement(tx, &domain.StockMovement{
+			ProductID: reservation.ProductID,
+			Delta:     -reservation.Quantity,
+			Reason:    domain.StockMovementSale,
+			OrderID:   &reservation.OrderID,
+		}); err != nil {
+			return errors.New("stock no longer available for this order")
+		}
+
+		if err := tx.Model(&reservation).Update("status", domain.StockReservationConverted).Error; err != nil {
+			return errors.New("failed to update stock reservation")
+		}
+	}
+	return nil
+}
+
+// releaseReservations lepas reservasi order yang ditolak, stok yang sudah terjual dikembalikan
+func releaseReservations(tx *gorm.DB, orderID string) error {
+	var reservations []domain.StockReservation
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("order_id = ? AND status IN ?", orderID, []domain.StockReservationStatus{domain.StockReservationActive, domain.StockReservationConverted}).
+		Find(&reservations).Error; err != nil {
+		return errors.New("failed to retrieve stock reservations")
+	}
+
+	for i := range reservations {
+		if err := releaseReservation(tx, &reservations[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func releaseReservation(tx *gorm.DB, reservation *domain.StockReservation) error {
+	if reservation.Status == domain.StockReservationConverted {
+		// Order yang sudah terjual dibatalkan, stok masuk kembali lewat ledger
+		if err := applyStockMovement(tx, &domain.StockMovement{
+			ProductID: reservation.ProductID,
+			Delta:     reservation.Quantity,
+			Reason:    domain.StockMovementCancellation,
+			OrderID:   &reservation.OrderID,
+		}); err != nil {
+			return err
+		}
+	} else if err := tx.Model(&domain.Product{}).Where("id = ?", reservation.ProductID).
+		Update("reserved", gorm.Expr("reserved - ?", reservation.Quantity)).Error; err != nil {
+		return errors.New("failed to release stock")
+	}
+	if err := tx.Model(reservation).Update("status", domain.StockReservationReleased).Error; err != nil {
+		return errors.New("failed to update stock reservation")
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"butik/internal/domain"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+type VoucherRepo interface {
+	CreateVoucher(voucher domain.Voucher) (*domain.Voucher, error)
+	GetAllVouchers(offset, limit int) ([]domain.Voucher, int, error)
+	GetVoucherByID(id uint) (*domain.Voucher, error)
+	GetVoucherByCode(code string) (*domain.Voucher, error)
+	UpdateVoucher(id uint, voucher domain.Voucher) (*domain.Voucher, error)
+	DeleteVoucher(id uint) error
+	CountUsageByWhatsapp(voucherID uint, whatsapp string) (int, error)
+}
+
+type voucherRepo struct {
+	db *gorm.DB
+}
+
+func NewVoucherRepo(db *gorm.DB) VoucherRepo {
+	return &voucherRepo{db: db}
+}
+
+func (r *voucherRepo) CreateVoucher(voucher domain.Voucher) (*domain.Voucher, error) {
+	result := r.db.Create(&voucher)
+	if result.Error != nil {
+		return nil, errors.New("failed to create voucher")
+	}
+	return &voucher, nil
+}
+
+func (r *voucherRepo) GetAllVouchers(offset, limit int) ([]domain.Voucher, int, error) {
+	var vouchers []domain.Voucher
+	var total int64
+
+	if err := r.db.Model(&domain.Voucher{}).Count(&total).Error; err != nil {
+		return nil, 0, errors.New("failed to count vouchers")
+	}
+
+	if err := r.db.Preload("Categories").Preload("Products").Order("created_at DESC").Offset(offset).Limit(limit).Find(&vouchers).Error; err != nil {
+		return nil, 0, errors.New("failed to retrieve vouchers")
+	}
+
+	return vouchers, int(total), nil
+}
+
+func (r *voucherRepo) GetVoucherByID(id uint) (*domain.Voucher, error) {
+	voucher := &domain.Voucher{}
+	result := r.db.Preload("Categories").Preload("Products").First(voucher, id)
+	if result.Error != nil {
+		return nil, errors.New("voucher not found")
+	}
+	return voucher, nil
+}
+
+func (r *voucherRepo) GetVoucherByCode(code string) (*domain.Voucher, error) {
+	voucher := &domain.Voucher{}
+	result := r.db.Preload("Categories").Preload("Products").Where("code = ?", code).First(voucher)
+	if result.Error != nil {
+		return nil, errors.New("voucher not found")
+	}
+	return voucher, nil
+}
+
+func (r *voucherRepo) UpdateVoucher(id uint, updatedVoucher domain.Voucher) (*domain.Voucher, error) {
+	voucher, err := r.GetVoucherByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	voucher.Code = updatedVoucher.Code
+	voucher.Description = updatedVoucher.Description
+	voucher.Type = updatedVoucher.Type
+	voucher.Value = updatedVoucher.Value
+	voucher.MinOrderValue = updatedVoucher.MinOrderValue
+	voucher.MaxDiscount = updatedVoucher.MaxDiscount
+	voucher.StartsAt = updatedVoucher.StartsAt
+	voucher.EndsAt = updatedVoucher.EndsAt
+	voucher.UsageLimit = updatedVoucher.UsageLimit
+	voucher.UsageLimitPerWhatsapp = updatedVoucher.UsageLimitPerWhatsapp
+	voucher.IsActive = updatedVoucher.IsActive
+
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		// used_count tidak ikut di-update, hanya berubah lewat transaksi order
+		if err := tx.Model(voucher).Select("*").Omit("ID", "UsedCount", "CreatedAt", "Categories", "Products").Updates(voucher).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(voucher).Association("Categories").Replace(updatedVoucher.Categories); err != nil {
+			return err
+		}
+		return tx.Model(voucher).Association("Products").Replace(updatedVoucher.Products)
+	})
+	if err != nil {
+		return nil, errors.New("failed to update voucher")
+	}
+
+	return r.GetVoucherByID(id)
+}
+
+func (r *voucherRepo) DeleteVoucher(id uint) error {
+	voucher, err := r.GetVoucherByID(id)
+	if err != nil {
+		return err
+	}
+	result := r.db.Select("Categories", "Products").Delete(voucher)
+	if result.Error != nil {
+		return errors.New("failed to delete voucher")
+	}
+	return nil
+}
+
+func (r *voucherRepo) CountUsageByWhatsapp(voucherID uint, whatsapp string) (int, error) {
+	var total int64
+	if err := r.db.Model(&domain.VoucherUsage{}).Where("voucher_id = ? AND whatsapp = ?", voucherID, whatsapp).Count(&total).Error; err != nil {
+		return 0, errors.New("failed to count voucher usage")
+	}
+	return int(total), nil
+}
@@ -0,0 +1,206 @@
+package repository
+
+import (
+	"butik/internal/domain"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type WebhookRepo interface {
+	CreateEndpoint(endpoint domain.WebhookEndpoint) (*domain.WebhookEndpoint, error)
+	GetAllEndpoints(offset, limit int) ([]domain.WebhookEndpoint, int, error)
+	GetActiveEndpoints() ([]domain.WebhookEndpoint, error)
+	GetEndpointByID(id uint) (*domain.WebhookEndpoint, error)
+	UpdateEndpoint(endpoint *domain.WebhookEndpoint) (*domain.WebhookEndpoint, error)
+	DeleteEndpoint(id uint) error
+	CreateDeliveries(deliveries []domain.WebhookDelivery) ([]domain.WebhookDelivery, error)
+	GetDueDeliveryIDs(now time.Time, limit int) ([]uint, error)
+	ClaimDelivery(id uint, now time.Time, lease time.Duration) (*domain.WebhookDelivery, error)
+	RecordAttempt(delivery *domain.WebhookDelivery, attempt domain.WebhookAttempt) error
+	RequeueDelivery(id uint, now time.Time) (*domain.WebhookDelivery, error)
+	GetDeliveries(endpointID uint, query domain.WebhookDeliveryListQuery, offset, limit int) ([]domain.WebhookDelivery, int, error)
+	GetDeliveryByID(id uint) (*domain.WebhookDelivery, error)
+}
+
+type webhookRepo struct {
+	db *gorm.DB
+}
+
+func NewWebhookRepo(db *gorm.DB) WebhookRepo {
+	return &webhookRepo{db: db}
+}
+
+func (r *webhookRepo) CreateEndpoint(endpoint domain.WebhookEndpoint) (*domain.WebhookEndpoint, error) {
+	if err := r.db.Create(&endpoint).Error; err != nil {
+		return nil, errors.New("failed to create webhook endpoint")
+	}
+	return &endpoint, nil
+}
+
+func (r *webhookRepo) GetAllEndpoints(offset, limit int) ([]domain.WebhookEndpoint, int, error) {
+	var total int64
+	if err := r.db.Model(&domain.WebhookEndpoint{}).Count(&total).Error; err != nil {
+		return nil, 0, errors.New("failed to count webhook endpoints")
+	}
+
+	var endpoints []domain.WebhookEndpoint
+	if err := r.db.Order("id ASC").Offset(offset).Limit(limit).Find(&endpoints).Error; err != nil {
+		return nil, 0, errors.New("failed to retrieve webhook endpoints")
+	}
+	return endpoints, int(total), nil
+}
+
+func (r *webhookRepo) GetActiveEndpoints() ([]domain.WebhookEndpoint, error) {
+	var endpoints []domain.WebhookEndpoint
+	if err := r.db.Where("is_active = ?", true).Order("id ASC").Find(&endpoints).Error; err != nil {
+		return nil, errors.New("failed to retrieve webhook endpoints")
+	}
+	return endpoints, nil
+}
+
+func (r *webhookRepo) GetEndpointByID(id uint) (*domain.WebhookEndpoint, error) {
+	var endpoint domain.WebhookEndpoint
+	if err := r.db.First(&endpoint, id).Error; err != nil {
+		return nil, errors.New("webhook endpoint not found")
+	}
+	return &endpoint, nil
+}
+
+func (r *webhookRepo) UpdateEndpoint(endpoint *domain.WebhookEndpoint) (*domain.WebhookEndpoint, error) {
+	err := r.db.Model(endpoint).Select("url", "description", "events", "is_active").Updates(endpoint).Error
+	if err != nil {
+		return nil, errors.New("failed to update webhook endpoint")
+	}
+	return r.GetEndpointByID(endpoint.ID)
+}
+
+func (r *webhookRepo) DeleteEndpoint(id uint) error {
+	result := r.db.Delete(&domain.WebhookEndpoint{}, id)
+	if result.Error != nil {
+		return errors.New("failed to delete webhook endpoint")
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("webhook endpoint not found")
+	}
+	return nil
+}
+
+func (r *webhookRepo) CreateDeliveries(deliveries []domain.WebhookDelivery) ([]domain.WebhookDelivery, error) {
+	if len(deliveries) == 0 {
+		return deliveries, nil
+	}
+	if err := r.db.Create(&deliveries).Error; err != nil {
+		return nil, errors.New("failed to create webhook deliveries")
+	}
+	return deliveries, nil
+}
+
+func (r *webhookRepo) GetDueDeliveryIDs(now time.Time, limit int) ([]uint, error) {
+	var ids []uint
+	err := r.db.Model(&domain.WebhookDelivery{}).
+		Where("status = ? AND next_attempt_at <= ? AND (locked_until IS NULL OR locked_until < ?)", domain.WebhookDeliveryPending, now, now).
+		Order("next_attempt_at ASC").
+		Limit(limit).
+		Pluck("id", &ids).Error
+	if err != nil {
+		return nil, errors.New("failed to retrieve due webhook deliveries")
+	}
+	return ids, nil
+}
+
+// ClaimDelivery kunci delivery selama lease supaya tidak dikirim dua kali
+// oleh pengiriman langsung dan job retry. Nil jika sudah diambil proses lain.
+// Endpoint ikut dimuat untuk URL dan secret terbaru.
+func (r *webhookRepo) ClaimDelivery(id uint, now time.Time, lease time.Duration) (*domain.WebhookDelivery, error) {
+	lockedUntil := now.Add(lease)
+	result := r.db.Model(&domain.WebhookDelivery{}).
+		Where("id = ? AND status = ? AND next_attempt_at <= ? AND (locked_until IS NULL OR locked_until < ?)", id, domain.WebhookDeliveryPending, now, now).
+		Update("locked_until", lockedUntil)
+	if result.Error != nil {
+		return nil, errors.New("failed to claim webhook delivery")
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+
+	var delivery domain.WebhookDelivery
+	if err := r.db.Preload("Endpoint").First(&delivery, id).Error; err != nil {
+		return nil, errors.New("webhook delivery not found")
+	}
+	return &delivery, nil
+}
+
+// RecordAttempt simpan hasil percobaan dan status terbaru delivery, lock dilepas
+func (r *webhookRepo) RecordAttempt(delivery *domain.WebhookDelivery, attempt domain.WebhookAttempt) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		attempt.DeliveryID = delivery.ID
+		if err := tx.Create(&attempt).Error; err != nil {
+			return errors.New("failed to record webhook attempt")
+		}
+
+		delivery.LockedUntil = nil
+		err := tx.Model(delivery).Select("status", "attempts", "next_attempt_at", "locked_until", "response_status", "last_error", "delivered_at").Updates(delivery).Error
+		if err != nil {
+			return errors.New("failed to update webhook delivery")
+		}
+		return nil
+	})
+}
+
+// RequeueDelivery jadwalkan delivery untuk segera dikirim, termasuk yang sudah berhasil.
+// Delivery yang sedang dikirim tidak bisa diantrikan ulang.
+func (r *webhookRepo) RequeueDelivery(id uint, now time.Time) (*domain.WebhookDelivery, error) {
+	result := r.db.Model(&domain.WebhookDelivery{}).
+		Where("id = ? AND (locked_until IS NULL OR locked_until < ?)", id, now).
+		Updates(map[string]interface{}{
+			"status":          domain.WebhookDeliveryPending,
+			"next_attempt_at": now,
+		})
+	if result.Error != nil {
+		return nil, errors.New("failed to requeue webhook delivery")
+	}
+	if result.RowsAffected == 0 {
+		if _, err := r.GetDeliveryByID(id); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("webhook delivery is in progress")
+	}
+	return r.GetDeliveryByID(id)
+}
+
+func (r *webhookRepo) GetDeliveries(endpointID uint, query domain.WebhookDeliveryListQuery, offset, limit int) ([]domain.WebhookDelivery, int, error) {
+	filtered := func() *gorm.DB {
+		db := r.db.Model(&domain.WebhookDelivery{}).Where("endpoint_id = ?", endpointID)
+		if query.Status != "" {
+			db = db.Where("status = ?", query.Status)
+		}
+		if query.Event != "" {
+			db = db.Where("event = ?", query.Event)
+		}
+		return db
+	}
+
+	var total int64
+	if err := filtered().Count(&total).Error; err != nil {
+		return nil, 0, errors.New("failed to count webhook deliveries")
+	}
+
+	var deliveries []domain.WebhookDelivery
+	if err := filtered().Order("created_at DESC, id DESC").Offset(offset).Limit(limit).Find(&deliveries).Error; err != nil {
+		return nil, 0, errors.New("failed to retrieve webhook deliveries")
+	}
+	return deliveries, int(total), nil
+}
+
+func (r *webhookRepo) GetDeliveryByID(id uint) (*domain.WebhookDelivery, error) {
+	var delivery domain.WebhookDelivery
+	err := r.db.Preload("AttemptLogs", func(db *gorm.DB) *gorm.DB {
+		return db.Order("attempt ASC")
+	}).First(&delivery, id).Error
+	if err != nil {
+		return nil, errors.New("webhook delivery not found")
+	}
+	return &delivery, nil
+}
@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"butik/internal/domain"
+	"butik/internal/domain/dto"
+	"butik/internal/repository"
+	"butik/pkg/utils"
+	"strings"
+)
+
+const (
+	customerAddressLimit  = 10
+	customerLinkBatchSize = 200
+)
+
+type CustomerUsecase interface {
+	GetAllCustomers(query domain.CustomerListQuery, offset, limit int) ([]*domain.CustomerResponse, int, error)
+	GetCustomerDetail(id uint, offset, limit int) (*domain.CustomerDetailResponse, int, error)
+	LinkUnlinkedOrders() (int, error)
+}
+
+type customerUsecase struct {
+	customerRepo  repository.CustomerRepo
+	orderRepo     repository.OrderRepo
+	notifications NotificationUsecase
+}
+
+func NewCustomerUsecase(customerRepo repository.CustomerRepo, orderRepo repository.OrderRepo, notifications NotificationUsecase) CustomerUsecase {
+	return &customerUsecase{
+		customerRepo:  customerRepo,
+		orderRepo:     orderRepo,
+		notifications: notifications,
+	}
+}
+
+func (u *customerUsecase) GetAllCustomers(query domain.CustomerListQuery, offset, limit int) ([]*domain.CustomerResponse, int, error) {
+	search := strings.TrimSpace(query.Search)
+	// Nomor tersimpan dalam E.164, jadi "0812-345" dicari sebagai "62812345"
+	customers, total, err := u.customerRepo.GetAllCustomers(search, utils.WhatsappSearchDigits(search), query.SortBy, query.SortOrder, offset, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	return dto.ToCustomerResponses(customers), total, nil
+}
+
+// GetCustomerDetail profil customer, alamat yang pernah dipakai dan riwayat order (terbaru dulu)
+func (u *customerUsecase) GetCustomerDetail(id uint, offset, limit int) (*domain.CustomerDetailResponse, int, error) {
+	customer, err := u.customerRepo.GetCustomerByID(id)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	addresses, err := u.customerRepo.GetCustomerAddresses(id, customerAddressLimit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	orders, total, err := u.orderRepo.GetAllOrders(domain.OrderFilter{CustomerID: id}, offset, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	orderResponses := dto.ToOrderResponses(orders)
+	if links, err := u.notifications.WhatsappLinks(orders); err == nil {
+		for i := range orderResponses {
+			orderResponses[i].WhatsappLinks = links[orders[i].ID]
+		}
+	}
+
+	return &domain.CustomerDetailResponse{
+		Customer:  *dto.ToCustomerResponse(customer),
+		Addresses: dto.ToCustomerAddressResponses(addresses),
+		Orders:    orderResponses,
+	}, total, nil
+}
+
+// LinkUnlinkedOrders hubungkan order lama (sebelum ada customer) secara bertahap
+func (u *customerUsecase) LinkUnlinkedOrders() (int, error) {
+	orders, err := u.customerRepo.GetUnlinkedOrders(customerLinkBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	linked := 0
+	for _, order := range orders {
+		customer := domain.Customer{
+			Whatsapp: utils.NormalizeWhatsapp(order.Whatsapp),
+			Name:     order.CustomerName,
+		}
+		if err := u.customerRepo.LinkOrder(order.ID, customer); err != nil {
+			return linked, err
+		}
+		linked++
+	}
+	return linked, nil
+}
@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"butik/internal/domain"
+	"butik/internal/domain/dto"
+	"butik/internal/repository"
+	"errors"
+)
+
+type DeliveryRateUsecase interface {
+	CreateDeliveryRate(req domain.CreateDeliveryRateRequest) (*domain.CreateDeliveryRateResponse, error)
+	GetAllDeliveryRates() ([]*domain.DeliveryRateResponse, error)
+	UpdateDeliveryRate(id uint, req domain.UpdateDeliveryRateRequest) (*domain.UpdateDeliveryRateResponse, error)
+	DeleteDeliveryRate(id uint) (*domain.DeleteDeliveryRateResponse, error)
+}
+
+type deliveryRateUsecase struct {
+	deliveryRateRepo repository.DeliveryRateRepo
+}
+
+func NewDeliveryRateUsecase(deliveryRateRepo repository.DeliveryRateRepo) DeliveryRateUsecase {
+	return &deliveryRateUsecase{deliveryRateRepo: deliveryRateRepo}
+}
+
+func (u *deliveryRateUsecase) CreateDeliveryRate(req domain.CreateDeliveryRateRequest) (*domain.CreateDeliveryRateResponse, error) {
+	rate, err := u.deliveryRateRepo.CreateDeliveryRate(domain.DeliveryRate{
+		MaxDistanceKm: req.MaxDistanceKm,
+		BaseFee:       req.BaseFee,
+		PerKmFee:      req.PerKmFee,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.CreateDeliveryRateResponse{
+		Message:      "Delivery rate created successfully",
+		DeliveryRate: *dto.ToDeliveryRateResponse(rate),
+	}, nil
+}
+
+func (u *deliveryRateUsecase) GetAllDeliveryRates() ([]*domain.DeliveryRateResponse, error) {
+	rates, err := u.deliveryRateRepo.GetAllDeliveryRates()
+	if err != nil {
+		return nil, err
+	}
+	return dto.ToDeliveryRateResponses(rates), nil
+}
+
+func (u *deliveryRateUsecase) UpdateDeliveryRate(id uint, req domain.UpdateDeliveryRateRequest) (*domain.UpdateDeliveryRateResponse, error) {
+	rate, err := u.deliveryRateRepo.UpdateDeliveryRate(id, domain.DeliveryRate{
+		MaxDistanceKm: req.MaxDistanceKm,
+		BaseFee:       req.BaseFee,
+		PerKmFee:      req.PerKmFee,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.UpdateDeliveryRateResponse{
+		Message:      "Delivery rate updated successfully",
+		DeliveryRate: *dto.ToDeliveryRateResponse(rate),
+	}, nil
+}
+
+func (u *deliveryRateUsecase) DeleteDeliveryRate(id uint) (*domain.DeleteDeliveryRateResponse, error) {
+	if err := u.deliveryRateRepo.DeleteDeliveryRate(id); err != nil {
+		return nil, errors.New("failed to delete delivery rate")
+	}
+	return &domain.DeleteDeliveryRateResponse{
+		Message: "Delivery rate deleted successfully",
+	}, nil
+}
@@ -0,0 +1,181 @@
+package usecase
+
+import (
+	"butik/internal/domain"
+	"butik/internal/domain/dto"
+	"butik/internal/repository"
+	"butik/pkg/utils"
+	"errors"
+	"strings"
+	"time"
+)
+
+type DeliveryZoneUsecase interface {
+	CreateDeliveryZone(req domain.CreateDeliveryZoneRequest) (*domain.CreateDeliveryZoneResponse, error)
+	GetAllDeliveryZones() ([]*domain.DeliveryZoneResponse, error)
+	GetDeliveryZoneByID(id uint) (*domain.DeliveryZoneResponse, error)
+	LookupDeliveryZone(latitude, longitude float64) (*domain.DeliveryZoneLookupResponse, error)
+	UpdateDeliveryZone(id uint, req domain.UpdateDeliveryZoneRequest) (*domain.UpdateDeliveryZoneResponse, error)
+	DeleteDeliveryZone(id uint) (*domain.DeleteDeliveryZoneResponse, error)
+}
+
+type deliveryZoneUsecase struct {
+	deliveryZoneRepo repository.DeliveryZoneRepo
+	location         *time.Location
+}
+
+func NewDeliveryZoneUsecase(deliveryZoneRepo repository.DeliveryZoneRepo, location *time.Location) DeliveryZoneUsecase {
+	return &deliveryZoneUsecase{
+		deliveryZoneRepo: deliveryZoneRepo,
+		location:         location,
+	}
+}
+
+var weekdayCodes = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+func (u *deliveryZoneUsecase) CreateDeliveryZone(req domain.CreateDeliveryZoneRequest) (*domain.CreateDeliveryZoneResponse, error) {
+	zone, err := buildDeliveryZone(domain.UpdateDeliveryZoneRequest(req))
+	if err != nil {
+		return nil, err
+	}
+
+	createdZone, err := u.deliveryZoneRepo.CreateDeliveryZone(*zone)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.CreateDeliveryZoneResponse{
+		Message:      "Delivery zone created successfully",
+		DeliveryZone: *dto.ToDeliveryZoneResponse(createdZone, true),
+	}, nil
+}
+
+func (u *deliveryZoneUsecase) GetAllDeliveryZones() ([]*domain.DeliveryZoneResponse, error) {
+	zones, err := u.deliveryZoneRepo.GetAllDeliveryZones()
+	if err != nil {
+		return nil, err
+	}
+	return dto.ToDeliveryZoneResponses(zones), nil
+}
+
+func (u *deliveryZoneUsecase) GetDeliveryZoneByID(id uint) (*domain.DeliveryZoneResponse, error) {
+	zone, err := u.deliveryZoneRepo.GetDeliveryZoneByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return dto.ToDeliveryZoneResponse(zone, true), nil
+}
+
+func (u *deliveryZoneUsecase) LookupDeliveryZone(latitude, longitude float64) (*domain.DeliveryZoneLookupResponse, error) {
+	zones, err := u.deliveryZoneRepo.GetActiveDeliveryZones()
+	if err != nil {
+		return nil, err
+	}
+
+	zone := findDeliveryZone(zones, latitude, longitude)
+	if zone == nil {
+		return nil, errors.New("location is outside our delivery zones")
+	}
+
+	return &domain.DeliveryZoneLookupResponse{
+		Zone:             *dto.ToDeliveryZoneResponse(zone, false),
+		NextDeliveryDate: nextDeliveryDate(zone, time.Now().In(u.location)).Format("2006-01-02"),
+	}, nil
+}
+
+func (u *deliveryZoneUsecase) UpdateDeliveryZone(id uint, req domain.UpdateDeliveryZoneRequest) (*domain.UpdateDeliveryZoneResponse, error) {
+	zone, err := buildDeliveryZone(req)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedZone, err := u.deliveryZoneRepo.UpdateDeliveryZone(id, *zone)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.UpdateDeliveryZoneResponse{
+		Message:      "Delivery zone updated successfully",
+		DeliveryZone: *dto.ToDeliveryZoneResponse(updatedZone, true),
+	}, nil
+}
+
+func (u *deliveryZoneUsecase) DeleteDeliveryZone(id uint) (*domain.DeleteDeliveryZoneResponse, error) {
+	if err := u.deliveryZoneRepo.DeleteDeliveryZone(id); err != nil {
+		return nil, err
+	}
+	return &domain.DeleteDeliveryZoneResponse{
+		Message: "Delivery zone deleted successfully",
+	}, nil
+}
+
+func buildDeliveryZone(req domain.UpdateDeliveryZoneRequest) (*domain.DeliveryZone, error) {
+	if _, err := utils.ParseGeoJSONPolygons(req.Geometry); err != nil {
+		return nil, err
+	}
+
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	// Simpan hari unik sesuai urutan minggu
+	days := make([]string, 0, len(req.AllowedDays))
+	for _, code := range []string{"mon", "tue", "wed", "thu", "fri", "sat", "sun"} {
+		for _, day := range req.AllowedDays {
+			if strings.ToLower(day) == code {
+				days = append(days, code)
+				break
+			}
+		}
+	}
+
+	return &domain.DeliveryZone{
+		Name:        req.Name,
+		Fee:         req.Fee,
+		AllowedDays: strings.Join(days, ","),
+		IsActive:    isActive,
+		Geometry:    string(req.Geometry),
+	}, nil
+}
+
+// findDeliveryZone zona pertama (ongkir termurah) yang memuat titik, nil jika tidak ada
+func findDeliveryZone(zones []domain.DeliveryZone, latitude, longitude float64) *domain.DeliveryZone {
+	for i := range zones {
+		polygons, err := utils.ParseGeoJSONPolygons([]byte(zones[i].Geometry))
+		if err != nil {
+			continue
+		}
+		if utils.PointInPolygons(latitude, longitude, polygons) {
+			return &zones[i]
+		}
+	}
+	return nil
+}
+
+// nextDeliveryDate hari kirim terdekat mulai dari hari ini
+func nextDeliveryDate(zone *domain.DeliveryZone, now time.Time) time.Time {
+	allowed := map[time.Weekday]bool{}
+	for _, day := range strings.Split(zone.AllowedDays, ",") {
+		if weekday, ok := weekdayCodes[day]; ok {
+			allowed[weekday] = true
+		}
+	}
+
+	date := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	for i := 0; i < 7; i++ {
+		if allowed[date.Weekday()] {
+			return date
+		}
+		date = date.AddDate(0, 0, 1)
+	}
+	return date
+}
@@ -0,0 +1,159 @@
+package usecase
+
+import (
+	"butik/internal/domain"
+	"butik/internal/repository"
+	"errors"
+	"time"
+)
+
+type MessageTemplateUsecase interface {
+	GetAllTemplates() ([]*domain.MessageTemplateResponse, error)
+	GetTemplate(event domain.NotificationEvent, language string) (*domain.MessageTemplateResponse, error)
+	SaveTemplate(event domain.NotificationEvent, language string, req domain.SaveMessageTemplateRequest, userID *uint) (*domain.SaveMessageTemplateResponse, error)
+	ResetTemplate(event domain.NotificationEvent, language string) (*domain.SaveMessageTemplateResponse, error)
+	PreviewTemplate(req domain.PreviewMessageTemplateRequest) (*domain.PreviewMessageTemplateResponse, error)
+	GetVariables() []domain.TemplateVariable
+}
+
+type messageTemplateUsecase struct {
+	templateRepo repository.MessageTemplateRepo
+	orderRepo    repository.OrderRepo
+	store        domain.StoreProfile
+}
+
+func NewMessageTemplateUsecase(templateRepo repository.MessageTemplateRepo, orderRepo repository.OrderRepo, store domain.StoreProfile) MessageTemplateUsecase {
+	return &messageTemplateUsecase{
+		templateRepo: templateRepo,
+		orderRepo:    orderRepo,
+		store:        store,
+	}
+}
+
+// GetAllTemplates semua kombinasi event dan bahasa beserta template yang berlaku
+func (u *messageTemplateUsecase) GetAllTemplates() ([]*domain.MessageTemplateResponse, error) {
+	stored, err := u.templateRepo.GetAllTemplates()
+	if err != nil {
+		return nil, err
+	}
+
+	custom := make(map[string]*domain.MessageTemplate, len(stored))
+	for i := range stored {
+		custom[string(stored[i].Event)+"/"+stored[i].Language] = &stored[i]
+	}
+
+	var responses []*domain.MessageTemplateResponse
+	for _, language := range []string{domain.LanguageIndonesian, domain.LanguageEnglish} {
+		for _, event := range notificationEvents {
+			tmpl := defaultNotificationTemplates[language][event]
+			responses = append(responses, toMessageTemplateResponse(event, language, tmpl, custom[string(event)+"/"+language]))
+		}
+	}
+	return responses, nil
+}
+
+func (u *messageTemplateUsecase) GetTemplate(event domain.NotificationEvent, language string) (*domain.MessageTemplateResponse, error) {
+	tmpl, stored, err := resolveNotificationTemplate(u.templateRepo, event, language)
+	if err != nil {
+		return nil, err
+	}
+	return toMessageTemplateResponse(event, language, tmpl, stored), nil
+}
+
+// SaveTemplate simpan template setelah sintaks dan variabelnya lolos dirender dengan data contoh
+func (u *messageTemplateUsecase) SaveTemplate(event domain.NotificationEvent, language string, req domain.SaveMessageTemplateRequest, userID *uint) (*domain.SaveMessageTemplateResponse, error) {
+	if err := validateTemplateKey(event, language); err != nil {
+		return nil, err
+	}
+
+	tmpl := notificationTemplate{Subject: req.Subject, Body: req.Body}
+	if err := validateNotificationTemplate(tmpl); err != nil {
+		return nil, errors.New("invalid template: " + err.Error())
+	}
+
+	stored, err := u.templateRepo.SaveTemplate(domain.MessageTemplate{
+		Event:     event,
+		Language:  language,
+		Subject:   req.Subject,
+		Body:      req.Body,
+		UpdatedBy: userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.SaveMessageTemplateResponse{
+		Message:  "Message template saved successfully",
+		Template: *toMessageTemplateResponse(event, language, tmpl, stored),
+	}, nil
+}
+
+// ResetTemplate hapus template admin, kembali ke template bawaan
+func (u *messageTemplateUsecase) ResetTemplate(event domain.NotificationEvent, language string) (*domain.SaveMessageTemplateResponse, error) {
+	if err := validateTemplateKey(event, language); err != nil {
+		return nil, err
+	}
+	if err := u.templateRepo.DeleteTemplate(event, language); err != nil {
+		return nil, err
+	}
+
+	return &domain.SaveMessageTemplateResponse{
+		Message:  "Message template reset to default",
+		Template: *toMessageTemplateResponse(event, language, defaultNotificationTemplates[language][event], nil),
+	}, nil
+}
+
+// PreviewTemplate render template (draft atau yang berlaku) dengan data order sungguhan
+func (u *messageTemplateUsecase) PreviewTemplate(req domain.PreviewMessageTemplateRequest) (*domain.PreviewMessageTemplateResponse, error) {
+	order, err := u.orderRepo.GetOrderByID(req.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	language := req.Language
+	if language == "" {
+		language = u.store.Language
+	}
+
+	tmpl, _, err := resolveNotificationTemplate(u.templateRepo, req.Event, language)
+	if err != nil {
+		return nil, err
+	}
+	if req.Body != "" {
+		tmpl = notificationTemplate{Subject: req.Subject, Body: req.Body}
+	}
+
+	subject, body, err := renderNotification(tmpl, buildNotificationData(order, u.store))
+	if err != nil {
+		return nil, errors.New("invalid template: " + err.Error())
+	}
+
+	return &domain.PreviewMessageTemplateResponse{
+		Event:    req.Event,
+		Language: language,
+		OrderID:  order.ID,
+		Subject:  subject,
+		Body:     body,
+	}, nil
+}
+
+func (u *messageTemplateUsecase) GetVariables() []domain.TemplateVariable {
+	return notificationVariables
+}
+
+func toMessageTemplateResponse(event domain.NotificationEvent, language string, tmpl notificationTemplate, stored *domain.MessageTemplate) *domain.MessageTemplateResponse {
+	response := &domain.MessageTemplateResponse{
+		Event:     event,
+		Language:  language,
+		Subject:   tmpl.Subject,
+		Body:      tmpl.Body,
+		IsDefault: stored == nil,
+	}
+	if stored != nil {
+		response.Subject = stored.Subject
+		response.Body = stored.Body
+		updatedAt := stored.UpdatedAt.Format(time.RFC3339)
+		response.UpdatedAt = &updatedAt
+	}
+	return response
+}
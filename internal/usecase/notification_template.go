@@ -0,0 +1,309 @@
+package usecase
+
+import (
+	"butik/internal/domain"
+	"butik/internal/infrastructure"
+	"butik/internal/repository"
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// NotificationData variabel yang bisa dipakai di template pesan
+type NotificationData struct {
+	StoreName        string
+	CustomerName     string
+	OrderID          string
+	OrderNumber      string
+	Items            string
+	Subtotal         string
+	DeliveryFee      string
+	Total            string
+	Status           string
+	FulfilmentMethod string
+	PickupCode       string
+	Courier          string
+	AirwayBill       string
+	TrackingURL      string
+	PaymentInfo      string
+	PaymentMethod    string
+	PaymentURL       string
+}
+
+// notificationVariables dokumentasi variabel untuk admin, urutan sama dengan NotificationData
+var notificationVariables = []domain.TemplateVariable{
+	{Name: "StoreName", Description: "Store name"},
+	{Name: "CustomerName", Description: "Customer name on the order"},
+	{Name: "OrderID", Description: "Order ID"},
+	{Name: "OrderNumber", Description: "Readable order number, e.g. BTK-202610-00042"},
+	{Name: "Items", Description: "One line per item: name, quantity and line total"},
+	{Name: "Subtotal", Description: "Items subtotal, e.g. Rp 150.000"},
+	{Name: "DeliveryFee", Description: "Delivery fee"},
+	{Name: "Total", Description: "Total price"},
+	{Name: "Status", Description: "Order status"},
+	{Name: "FulfilmentMethod", Description: "delivery or pickup"},
+	{Name: "PickupCode", Description: "Pickup code for pickup orders"},
+	{Name: "Courier", Description: "Courier name, e.g. JNE"},
+	{Name: "AirwayBill", Description: "Courier airway bill number"},
+	{Name: "TrackingURL", Description: "Link to the public order page"},
+	{Name: "PaymentInfo", Description: "Store bank account / payment instructions"},
+	{Name: "PaymentMethod", Description: "manual_transfer or gateway"},
+	{Name: "PaymentURL", Description: "Payment gateway page for gateway orders awaiting payment"},
+}
+
+// sampleNotificationData untuk validasi template saat disimpan
+var sampleNotificationData = NotificationData{
+	StoreName:        "Butik",
+	CustomerName:     "Sari",
+	OrderID:          "V1StGXR8_Z5jdHi6B-myT",
+	OrderNumber:      "BTK-202610-00042",
+	Items:            "- Gaun x2 (Rp 150.000)",
+	Subtotal:         "Rp 150.000",
+	DeliveryFee:      "Rp 10.000",
+	Total:            "Rp 160.000",
+	Status:           string(domain.OrderStatusPending),
+	FulfilmentMethod: string(domain.FulfilmentDelivery),
+	PickupCode:       "AB23CD",
+	Courier:          "JNE",
+	AirwayBill:       "JNE1234567890",
+	TrackingURL:      "https://example.com/orders/V1StGXR8_Z5jdHi6B-myT",
+	PaymentInfo:      "BCA 1234567890 a.n. Butik",
+	PaymentMethod:    string(domain.PaymentMethodGateway),
+	PaymentURL:       "https://app.sandbox.midtrans.com/snap/v4/redirection/66e4fa55-fdac-4ef9-91b5-733b97d1b862",
+}
+
+type notificationTemplate struct {
+	Subject string
+	Body    string
+}
+
+// defaultNotificationTemplates pesan bawaan per bahasa dan event, subject hanya dipakai untuk email
+var defaultNotificationTemplates = map[string]map[domain.NotificationEvent]notificationTemplate{
+	domain.LanguageIndonesian: {
+		domain.NotificationOrderCreated: {
+			Subject: "Pesanan {{.OrderNumber}} diterima",
+			Body: `Halo {{.CustomerName}}, terima kasih sudah belanja di {{.StoreName}}.
+
+Pesanan {{.OrderNumber}} sudah kami terima:
+{{.Items}}
+Total: {{.Total}}
+
+{{if eq .PaymentMethod "gateway"}}Silakan selesaikan pembayaran{{if .PaymentURL}} di {{.PaymentURL}}{{end}}, pesanan otomatis kami proses setelah pembayaran diterima.{{else}}Bukti transfer sedang kami cek, kami kabari lagi setelah pembayaran terverifikasi.{{end}}{{if .TrackingURL}}
+Cek pesanan: {{.TrackingURL}}{{end}}`,
+		},
+		domain.NotificationPaymentVerified: {
+			Subject: "Pembayaran pesanan {{.OrderNumber}} terverifikasi",
+			Body: `Halo {{.CustomerName}}, pembayaran pesanan {{.OrderNumber}} sebesar {{.Total}} sudah kami terima.
+{{if eq .FulfilmentMethod "pickup"}}
+Pesanan bisa diambil di toko dengan kode {{.PickupCode}}.{{else}}
+Pesanan sedang kami siapkan untuk dikirim.{{end}}
+
+Terima kasih, {{.StoreName}}`,
+		},
+		domain.NotificationOrderRejected: {
+			Subject: "Pesanan {{.OrderNumber}} tidak dapat diproses",
+			Body: `Halo {{.CustomerName}}, mohon maaf pesanan {{.OrderNumber}} tidak dapat kami proses karena pembayaran belum dapat diverifikasi.
+
+Silakan hubungi kami untuk informasi lebih lanjut.
+{{.StoreName}}`,
+		},
+		domain.NotificationOrderShipped: {
+			Subject: "Pesanan {{.OrderNumber}} sudah dikirim",
+			Body: `Halo {{.CustomerName}}, pesanan {{.OrderNumber}} sudah dikirim via {{.Courier}} dengan nomor resi {{.AirwayBill}}.{{if .TrackingURL}}
+Lacak pesanan: {{.TrackingURL}}{{end}}
+
+Terima kasih, {{.StoreName}}`,
+		},
+		domain.NotificationPaymentReminder: {
+			Subject: "Menunggu pembayaran pesanan {{.OrderNumber}}",
+			Body: `Halo {{.CustomerName}}, pembayaran pesanan {{.OrderNumber}} sebesar {{.Total}} belum dapat kami verifikasi.{{if .PaymentInfo}}
+
+Pembayaran bisa ditransfer ke:
+{{.PaymentInfo}}{{end}}
+
+Mohon kirim bukti transfer jika sudah membayar. Terima kasih, {{.StoreName}}`,
+		},
+	},
+	domain.LanguageEnglish: {
+		domain.NotificationOrderCreated: {
+			Subject: "Order {{.OrderNumber}} received",
+			Body: `Hi {{.CustomerName}}, thank you for shopping at {{.StoreName}}.
+
+We have received order {{.OrderNumber}}:
+{{.Items}}
+Total: {{.Total}}
+
+{{if eq .PaymentMethod "gateway"}}Please complete the payment{{if .PaymentURL}} at {{.PaymentURL}}{{end}}, we will process your order as soon as it is received.{{else}}We are checking your transfer and will let you know once the payment is verified.{{end}}{{if .TrackingURL}}
+View order: {{.TrackingURL}}{{end}}`,
+		},
+		domain.NotificationPaymentVerified: {
+			Subject: "Payment for order {{.OrderNumber}} verified",
+			Body: `Hi {{.CustomerName}}, we have received the payment of {{.Total}} for order {{.OrderNumber}}.
+{{if eq .FulfilmentMethod "pickup"}}
+Your order is ready for pickup at the store with code {{.PickupCode}}.{{else}}
+We are preparing your order for delivery.{{end}}
+
+Thank you, {{.StoreName}}`,
+		},
+		domain.NotificationOrderRejected: {
+			Subject: "Order {{.OrderNumber}} could not be processed",
+			Body: `Hi {{.CustomerName}}, we are sorry, order {{.OrderNumber}} could not be processed because the payment could not be verified.
+
+Please contact us for more information.
+{{.StoreName}}`,
+		},
+		domain.NotificationOrderShipped: {
+			Subject: "Order {{.OrderNumber}} has been shipped",
+			Body: `Hi {{.CustomerName}}, order {{.OrderNumber}} has been shipped via {{.Courier}} with airway bill {{.AirwayBill}}.{{if .TrackingURL}}
+Track your order: {{.TrackingURL}}{{end}}
+
+Thank you, {{.StoreName}}`,
+		},
+		domain.NotificationPaymentReminder: {
+			Subject: "Awaiting payment for order {{.OrderNumber}}",
+			Body: `Hi {{.CustomerName}}, we have not been able to verify the payment of {{.Total}} for order {{.OrderNumber}} yet.{{if .PaymentInfo}}
+
+Please transfer to:
+{{.PaymentInfo}}{{end}}
+
+If you have already paid, please send us the transfer receipt. Thank you, {{.StoreName}}`,
+		},
+	},
+}
+
+var notificationEvents = []domain.NotificationEvent{
+	domain.NotificationOrderCreated,
+	domain.NotificationPaymentVerified,
+	domain.NotificationOrderRejected,
+	domain.NotificationOrderShipped,
+	domain.NotificationPaymentReminder,
+}
+
+func buildNotificationData(order *domain.Order, store domain.StoreProfile) NotificationData {
+	var items strings.Builder
+	for _, item := range order.OrderItems {
+		fmt.Fprintf(&items, "- %s x%d (%s)\n", item.Product.Name, item.Quantity, infrastructure.FormatRupiah(item.PriceAtPurchase*float64(item.Quantity)))
+	}
+
+	orderNumber := order.OrderNumber
+	if orderNumber == "" {
+		orderNumber = order.ID
+	}
+
+	// Link bayar tagihan gateway yang masih menunggu pembayaran
+	var paymentURL string
+	for _, payment := range order.Payments {
+		if payment.Status == domain.PaymentStatusPending {
+			paymentURL = payment.PaymentURL
+		}
+	}
+
+	var trackingURL string
+	if store.OrderURL != "" {
+		trackingURL = strings.ReplaceAll(store.OrderURL, "{id}", order.ID)
+	}
+
+	return NotificationData{
+		StoreName:        store.Name,
+		CustomerName:     order.CustomerName,
+		OrderID:          order.ID,
+		OrderNumber:      orderNumber,
+		Items:            strings.TrimRight(items.String(), "\n"),
+		Subtotal:         infrastructure.FormatRupiah(order.Subtotal),
+		DeliveryFee:      infrastructure.FormatRupiah(order.DeliveryFee),
+		Total:            infrastructure.FormatRupiah(order.TotalPrice),
+		Status:           string(order.Status),
+		FulfilmentMethod: string(order.FulfilmentMethod),
+		PickupCode:       order.PickupCode,
+		Courier:          strings.ToUpper(order.Courier),
+		AirwayBill:       order.AirwayBill,
+		TrackingURL:      trackingURL,
+		PaymentInfo:      store.PaymentInfo,
+		PaymentMethod:    string(order.PaymentMethod),
+		PaymentURL:       paymentURL,
+	}
+}
+
+func renderTemplate(name, text string, data NotificationData) (string, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
+
+// renderNotification render subject dan body sekaligus
+func renderNotification(tmpl notificationTemplate, data NotificationData) (string, string, error) {
+	subject, err := renderTemplate("subject", tmpl.Subject, data)
+	if err != nil {
+		return "", "", fmt.Errorf("subject: %w", err)
+	}
+	body, err := renderTemplate("body", tmpl.Body, data)
+	if err != nil {
+		return "", "", fmt.Errorf("body: %w", err)
+	}
+	return subject, body, nil
+}
+
+// validateNotificationTemplate cek sintaks dan nama variabel dengan data contoh
+func validateNotificationTemplate(tmpl notificationTemplate) error {
+	_, _, err := renderNotification(tmpl, sampleNotificationData)
+	return err
+}
+
+func validateTemplateKey(event domain.NotificationEvent, language string) error {
+	templates, ok := defaultNotificationTemplates[language]
+	if !ok {
+		return errors.New("unsupported language")
+	}
+	if _, ok := templates[event]; !ok {
+		return errors.New("unknown notification event")
+	}
+	return nil
+}
+
+// resolveNotificationTemplate template yang disimpan admin, jika tidak ada pakai bawaan
+func resolveNotificationTemplate(templateRepo repository.MessageTemplateRepo, event domain.NotificationEvent, language string) (notificationTemplate, *domain.MessageTemplate, error) {
+	if err := validateTemplateKey(event, language); err != nil {
+		return notificationTemplate{}, nil, err
+	}
+
+	stored, err := templateRepo.GetTemplate(event, language)
+	if err != nil {
+		return notificationTemplate{}, nil, err
+	}
+	if stored != nil {
+		return notificationTemplate{Subject: stored.Subject, Body: stored.Body}, stored, nil
+	}
+	return defaultNotificationTemplates[language][event], nil, nil
+}
+
+// templateSet template admin yang dimuat sekali untuk banyak order
+type templateSet map[string]domain.MessageTemplate
+
+func loadTemplateSet(templateRepo repository.MessageTemplateRepo) (templateSet, error) {
+	stored, err := templateRepo.GetAllTemplates()
+	if err != nil {
+		return nil, err
+	}
+
+	set := make(templateSet, len(stored))
+	for _, tmpl := range stored {
+		set[string(tmpl.Event)+"/"+tmpl.Language] = tmpl
+	}
+	return set, nil
+}
+
+func (s templateSet) get(event domain.NotificationEvent, language string) notificationTemplate {
+	if stored, ok := s[string(event)+"/"+language]; ok {
+		return notificationTemplate{Subject: stored.Subject, Body: stored.Body}
+	}
+	return defaultNotificationTemplates[language][event]
+}
@@ -0,0 +1,282 @@
+package usecase
+
+import (
+	"butik/internal/domain"
+	"butik/internal/domain/dto"
+	"butik/internal/infrastructure"
+	"butik/internal/repository"
+	"butik/pkg/utils"
+	"errors"
+	"log"
+	"time"
+)
+
+const (
+	notificationBatchSize = 50
+	notificationLease     = 2 * time.Minute
+	notificationMaxDelay  = time.Hour
+)
+
+type NotificationUsecase interface {
+	NotifyOrder(event domain.NotificationEvent, orderID string) error
+	DeliverDueNotifications() (int, error)
+	GetAllNotifications(query domain.NotificationListQuery, offset, limit int) ([]*domain.NotificationResponse, int, error)
+	GetNotificationByID(id uint) (*domain.NotificationResponse, error)
+	RetryNotification(id uint) (*domain.RetryNotificationResponse, error)
+	WhatsappLinks(orders []domain.Order) (map[string][]domain.WhatsappLink, error)
+}
+
+type notificationUsecase struct {
+	notificationRepo repository.NotificationRepo
+	orderRepo        repository.OrderRepo
+	templateRepo     repository.MessageTemplateRepo
+	providers        map[domain.NotificationChannel]infrastructure.NotificationProvider
+	store            domain.StoreProfile
+	maxAttempts      int
+}
+
+func NewNotificationUsecase(notificationRepo repository.NotificationRepo, orderRepo repository.OrderRepo, templateRepo repository.MessageTemplateRepo, providers []infrastructure.NotificationProvider, store domain.StoreProfile, maxAttempts int) NotificationUsecase {
+	byChannel := make(map[domain.NotificationChannel]infrastructure.NotificationProvider, len(providers))
+	for _, provider := range providers {
+		byChannel[provider.Channel()] = provider
+	}
+
+	return &notificationUsecase{
+		notificationRepo: notificationRepo,
+		orderRepo:        orderRepo,
+		templateRepo:     templateRepo,
+		providers:        byChannel,
+		store:            store,
+		maxAttempts:      maxAttempts,
+	}
+}
+
+// NotifyOrder render pesan event untuk tiap channel yang aktif, simpan, lalu kirim di background.
+// Pesan yang gagal dikirim diulang oleh job deliver_notifications.
+func (u *notificationUsecase) NotifyOrder(event domain.NotificationEvent, orderID string) error {
+	order, err := u.orderRepo.GetOrderByID(orderID)
+	if err != nil {
+		return err
+	}
+
+	tmpl, _, err := resolveNotificationTemplate(u.templateRepo, event, u.orderLanguage(order))
+	if err != nil {
+		return err
+	}
+
+	subject, body, err := renderNotification(tmpl, buildNotificationData(order, u.store))
+	if err != nil {
+		// Template rusak tidak boleh membuat customer tanpa kabar, pakai bawaan
+		log.Printf("Message template %s failed, using default: %v", event, err)
+		subject, body, err = renderNotification(defaultNotificationTemplates[u.orderLanguage(order)][event], buildNotificationData(order, u.store))
+		if err != nil {
+			return err
+		}
+	}
+
+	recipients := map[domain.NotificationChannel]string{
+		domain.NotificationChannelWhatsapp: order.Whatsapp,
+		domain.NotificationChannelEmail:    order.Email,
+	}
+
+	now := time.Now()
+	var notifications []domain.Notification
+	for channel, recipient := range recipients {
+		provider, ok := u.providers[channel]
+		if !ok || recipient == "" {
+			continue
+		}
+		notifications = append(notifications, domain.Notification{
+			OrderID:       order.ID,
+			Event:         event,
+			Channel:       channel,
+			Provider:      provider.Name(),
+			Recipient:     recipient,
+			Subject:       subject,
+			Body:          body,
+			Status:        domain.NotificationStatusPending,
+			NextAttemptAt: now,
+		})
+	}
+
+	created, err := u.notificationRepo.CreateNotifications(notifications)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		for _, notification := range created {
+			if err := u.deliver(notification.ID); err != nil {
+				log.Println("Notification delivery failed:", err)
+			}
+		}
+	}()
+	return nil
+}
+
+// orderLanguage bahasa pesan order, default bahasa toko
+func (u *notificationUsecase) orderLanguage(order *domain.Order) string {
+	if _, ok := defaultNotificationTemplates[order.Language]; ok {
+		return order.Language
+	}
+	return u.store.Language
+}
+
+// DeliverDueNotifications kirim ulang notifikasi yang jadwal retry-nya sudah lewat
+func (u *notificationUsecase) DeliverDueNotifications() (int, error) {
+	ids, err := u.notificationRepo.GetDueNotificationIDs(time.Now(), notificationBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	for _, id := range ids {
+		if err := u.deliver(id); err != nil {
+			log.Println("Notification delivery failed:", err)
+			continue
+		}
+		delivered++
+	}
+	return delivered, nil
+}
+
+// deliver satu percobaan kirim, error dikembalikan hanya jika pesan gagal terkirim
+func (u *notificationUsecase) deliver(id uint) error {
+	notification, err := u.notificationRepo.ClaimNotification(id, time.Now(), notificationLease)
+	if err != nil || notification == nil {
+		return err
+	}
+
+	provider, ok := u.providers[notification.Channel]
+	sendErr := errors.New("no provider configured for channel " + string(notification.Channel))
+	start := time.Now()
+	if ok {
+		notification.Provider = provider.Name()
+		sendErr = provider.Send(infrastructure.NotificationMessage{
+			Recipient: notification.Recipient,
+			Subject:   notification.Subject,
+			Body:      notification.Body,
+		})
+	}
+
+	now := time.Now()
+	notification.Attempts++
+	attempt := domain.NotificationAttempt{
+		Attempt:    notification.Attempts,
+		Success:    sendErr == nil,
+		DurationMs: now.Sub(start).Milliseconds(),
+	}
+
+	if sendErr == nil {
+		notification.Status = domain.NotificationStatusSent
+		notification.SentAt = &now
+		notification.LastError = ""
+	} else {
+		attempt.Error = sendErr.Error()
+		notification.LastError = sendErr.Error()
+		if notification.Attempts >= u.maxAttempts {
+			notification.Status = domain.NotificationStatusFailed
+		} else {
+			notification.NextAttemptAt = now.Add(notificationBackoff(notification.Attempts))
+		}
+	}
+
+	if err := u.notificationRepo.RecordAttempt(notification, attempt); err != nil {
+		return err
+	}
+	return sendErr
+}
+
+// notificationBackoff 1, 2, 4, 8 ... menit, maksimal satu jam
+func notificationBackoff(attempts int) time.Duration {
+	delay := time.Minute << (attempts - 1)
+	if delay <= 0 || delay > notificationMaxDelay {
+		return notificationMaxDelay
+	}
+	return delay
+}
+
+func (u *notificationUsecase) GetAllNotifications(query domain.NotificationListQuery, offset, limit int) ([]*domain.NotificationResponse, int, error) {
+	notifications, total, err := u.notificationRepo.GetAllNotifications(query, offset, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	return dto.ToNotificationResponses(notifications), total, nil
+}
+
+func (u *notificationUsecase) GetNotificationByID(id uint) (*domain.NotificationResponse, error) {
+	notification, err := u.notificationRepo.GetNotificationByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return dto.ToNotificationResponse(notification), nil
+}
+
+// RetryNotification kirim ulang sekarang, juga untuk notifikasi yang sudah failed
+func (u *notificationUsecase) RetryNotification(id uint) (*domain.RetryNotificationResponse, error) {
+	if _, err := u.notificationRepo.RequeueNotification(id, time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := u.deliver(id); err != nil {
+		log.Println("Notification delivery failed:", err)
+	}
+
+	notification, err := u.notificationRepo.GetNotificationByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	message := "Notification sent successfully"
+	if notification.Status != domain.NotificationStatusSent {
+		message = "Notification delivery failed"
+	}
+	return &domain.RetryNotificationResponse{
+		Message:      message,
+		Notification: *dto.ToNotificationResponse(notification),
+	}, nil
+}
+
+// WhatsappLinks link chat dan pesan sesuai status untuk tiap order (key order ID)
+func (u *notificationUsecase) WhatsappLinks(orders []domain.Order) (map[string][]domain.WhatsappLink, error) {
+	templates, err := loadTemplateSet(u.templateRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	links := make(map[string][]domain.WhatsappLink, len(orders))
+	for i := range orders {
+		order := &orders[i]
+		if utils.NormalizeWhatsapp(order.Whatsapp) == "" {
+			continue
+		}
+
+		orderLinks := []domain.WhatsappLink{{
+			Purpose: domain.WhatsappLinkChat,
+			URL:     whatsappLink(order.Whatsapp, ""),
+		}}
+
+		events := whatsappLinkEvents(order)
+		if len(events) > 0 {
+			language := u.orderLanguage(order)
+			data := buildNotificationData(order, u.store)
+			for _, purpose := range whatsappLinkOrder {
+				event, ok := events[purpose]
+				if !ok {
+					continue
+				}
+				_, message, err := renderNotification(templates.get(event, language), data)
+				if err != nil {
+					_, message, _ = renderNotification(defaultNotificationTemplates[language][event], data)
+				}
+				orderLinks = append(orderLinks, domain.WhatsappLink{
+					Purpose: purpose,
+					Message: message,
+					URL:     whatsappLink(order.Whatsapp, message),
+				})
+			}
+		}
+		links[order.ID] = orderLinks
+	}
+	return links, nil
+}
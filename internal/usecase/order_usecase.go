@@ -3,29 +3,85 @@ package usecase
 import (
 	"butik/internal/domain"
 	"butik/internal/domain/dto"
+	"butik/internal/infrastructure"
 	"butik/internal/repository"
+	"butik/pkg/utils"
 	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"strings"
+	"time"
 
 	gonanoid "github.com/matoous/go-nanoid/v2"
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+const (
+	// Tanpa karakter yang mirip (0/O, 1/I) supaya mudah dibacakan
+	pickupCodeAlphabet  = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	pickupQRPrefix      = "BUTIK-PICKUP:"
+	staleOrderBatchSize = 100
+	exportBatchSize     = 200
 )
 
 type OrderUsecase interface {
 	CreateOrder(req domain.CreateOrderRequest, proofOfPayment string) (*domain.CreateOrderResponse, error)
-	GetAllOrders(offset, limit int) ([]*domain.OrderResponse, int, error)
+	QuoteOrder(req domain.QuoteOrderRequest) (*domain.QuoteOrderResponse, error)
+	GetAllOrders(query domain.OrderListQuery, offset, limit int) ([]*domain.OrderResponse, int, error)
+	ExportOrders(query domain.ExportOrdersQuery, w io.Writer) error
 	GetOrderByID(id string) (*domain.OrderResponse, error)
 	UpdateOrderStatus(id string, req domain.UpdateOrderStatusRequest) (*domain.UpdateOrderStatusResponse, error)
+	AttachAirwayBill(id string, req domain.AttachAirwayBillRequest) (*domain.AttachAirwayBillResponse, error)
+	TrackOrder(id string) (*domain.OrderTrackingResponse, error)
+	CollectOrder(id string, req domain.CollectOrderRequest) (*domain.CollectOrderResponse, error)
+	GetPickupQRCode(id string) ([]byte, error)
+	GetInvoicePDF(id string) ([]byte, error)
+	CancelStalePendingOrders(maxAge time.Duration) (int, error)
+	ReleaseExpiredReservations() (int, error)
 	DeleteOrder(id string) error
 }
 
 type orderUsecase struct {
-	orderRepo   repository.OrderRepo
-	productRepo repository.ProductRepo
+	orderRepo         repository.OrderRepo
+	productRepo       repository.ProductRepo
+	voucherRepo       repository.VoucherRepo
+	promotionRepo     repository.PromotionRepo
+	deliveryRateRepo  repository.DeliveryRateRepo
+	deliveryZoneRepo  repository.DeliveryZoneRepo
+	deliveryConfig    domain.DeliveryConfig
+	courier           infrastructure.CourierProvider
+	reservationRepo   repository.StockReservationRepo
+	reservationTTL    time.Duration
+	alerts            infrastructure.AlertNotifier
+	lowStockThreshold int
+	invoices          infrastructure.InvoiceRenderer
+	orderNumbers      domain.OrderNumberFormat
+	notifications     NotificationUsecase
+	webhooks          WebhookUsecase
+	payments          PaymentUsecase
 }
 
-func NewOrderUsecase(orderRepo repository.OrderRepo, productRepo repository.ProductRepo) OrderUsecase {
+func NewOrderUsecase(orderRepo repository.OrderRepo, productRepo repository.ProductRepo, voucherRepo repository.VoucherRepo, promotionRepo repository.PromotionRepo, deliveryRateRepo repository.DeliveryRateRepo, deliveryZoneRepo repository.DeliveryZoneRepo, deliveryConfig domain.DeliveryConfig, courier infrastructure.CourierProvider, reservationRepo repository.StockReservationRepo, reservationTTL time.Duration, alerts infrastructure.AlertNotifier, lowStockThreshold int, invoices infrastructure.InvoiceRenderer, orderNumbers domain.OrderNumberFormat, notifications NotificationUsecase, webhooks WebhookUsecase, payments PaymentUsecase) OrderUsecase {
 	return &orderUsecase{
-		orderRepo:   orderRepo,
-		productRepo: productRepo,
+		orderRepo:         orderRepo,
+		productRepo:       productRepo,
+		voucherRepo:       voucherRepo,
+		promotionRepo:     promotionRepo,
+		deliveryRateRepo:  deliveryRateRepo,
+		deliveryZoneRepo:  deliveryZoneRepo,
+		deliveryConfig:    deliveryConfig,
+		courier:           courier,
+		reservationRepo:   reservationRepo,
+		reservationTTL:    reservationTTL,
+		alerts:            alerts,
+		lowStockThreshold: lowStockThreshold,
+		invoices:          invoices,
+		orderNumbers:      orderNumbers,
+		notifications:     notifications,
+		webhooks:          webhooks,
+		payments:          payments,
 	}
 }
 
@@ -36,28 +92,267 @@ func (u *orderUsecase) CreateOrder(req domain.CreateOrderRequest, proofOfPayment
 		return nil, errors.New("failed to generate order ID")
 	}
 
-	var totalPrice float64
-	var orderItems []domain.OrderItem
-	var stockUpdates []struct {
-		ProductID uint
-		NewStock  int
+	// Nomor disimpan dalam format E.164 supaya customer dan limit voucher konsisten
+	whatsapp, err := utils.ParseWhatsapp(req.Whatsapp)
+	if err != nil {
+		return nil, domain.RejectOrder(err.Error())
+	}
+
+	fulfilment := req.FulfilmentMethod
+	if fulfilment == "" {
+		fulfilment = domain.FulfilmentDelivery
+	}
+
+	paymentMethod := req.PaymentMethod
+	if paymentMethod == "" {
+		paymentMethod = domain.PaymentMethodManualTransfer
+	}
+	if paymentMethod == domain.PaymentMethodGateway && !u.payments.Enabled() {
+		return nil, domain.ErrPaymentGatewayUnavailable
+	}
+
+	pricing, err := u.priceOrder(orderID, pricingInput{
+		FulfilmentMethod: fulfilment,
+		Items:            req.Items,
+		VoucherCode:      req.VoucherCode,
+		Whatsapp:         whatsapp,
+		Latitude:         req.Latitude,
+		Longitude:        req.Longitude,
+		DestinationCity:  req.DestinationCity,
+		Courier:          req.Courier,
+		CourierService:   req.CourierService,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(pricing.Problems) > 0 {
+		return nil, domain.RejectOrder(pricing.Problems[0].Message)
+	}
+	if pricing.VoucherErr != nil {
+		return nil, pricing.VoucherErr
+	}
+	if pricing.DeliveryErr != nil {
+		return nil, pricing.DeliveryErr
+	}
+
+	var deliveryZoneID *uint
+	var deliveryZoneName string
+	if pricing.DeliveryZone != nil {
+		deliveryZoneID = &pricing.DeliveryZone.ID
+		deliveryZoneName = pricing.DeliveryZone.Name
 	}
 
+	var courier, courierService, destinationCity string
+	if pricing.CourierRate != nil {
+		courier = pricing.CourierRate.Courier
+		courierService = pricing.CourierRate.Service
+		destinationCity = req.DestinationCity
+	}
+
+	var pickupCode string
+	if fulfilment == domain.FulfilmentPickup {
+		pickupCode, err = gonanoid.Generate(pickupCodeAlphabet, 6)
+		if err != nil {
+			return nil, errors.New("failed to generate pickup code")
+		}
+	}
+
+	order := domain.Order{
+		ID:               orderID,
+		CustomerName:     req.CustomerName,
+		Whatsapp:         whatsapp,
+		Email:            strings.ToLower(req.Email),
+		Language:         req.Language,
+		MapAddress:       req.MapAddress,
+		Latitude:         req.Latitude,
+		Longitude:        req.Longitude,
+		AddressNote:      req.AddressNote,
+		FulfilmentMethod: fulfilment,
+		PickupCode:       pickupCode,
+		Subtotal:         pricing.Subtotal,
+		DiscountTotal:    pricing.DiscountTotal,
+		DistanceKm:       pricing.DistanceKm,
+		DeliveryFee:      pricing.DeliveryFee,
+		DeliveryZoneID:   deliveryZoneID,
+		DeliveryZone:     deliveryZoneName,
+		DeliveryDate:     pricing.DeliveryDate,
+		DestinationCity:  destinationCity,
+		Courier:          courier,
+		CourierService:   courierService,
+		ShippingWeight:   pricing.WeightGrams,
+		TotalPrice:       pricing.Total,
+		VoucherCode:      pricing.VoucherCode,
+		PaymentMethod:    paymentMethod,
+		ProofOfPayment:   proofOfPayment,
+		Status:           domain.OrderStatusPending,
+		OrderItems:       pricing.Items,
+		Discounts:        pricing.Discounts,
+		CreatedAt:        time.Now(),
+		Customer:         &domain.Customer{Whatsapp: whatsapp, Name: req.CustomerName},
+	}
+
+	// Stok ditahan sampai bukti transfer diverifikasi admin
+	expiresAt := time.Now().Add(u.reservationTTL)
+	for i := range pricing.Reservations {
+		pricing.Reservations[i].ExpiresAt = expiresAt
+	}
+
+	// Create order dengan transaction
+	createdOrder, err := u.orderRepo.CreateOrderWithTransaction(order, u.orderNumbers, pricing.Reservations, pricing.VoucherUsage)
+	if err != nil {
+		return nil, err
+	}
+
+	// Order tetap dibuat walaupun tagihan gagal, customer bisa minta tagihan baru
+	if paymentMethod == domain.PaymentMethodGateway {
+		payment, err := u.payments.CreateCharge(createdOrder)
+		if err != nil {
+			log.Printf("Failed to create payment for order %s: %v", createdOrder.ID, err)
+		} else {
+			createdOrder.Payments = append(createdOrder.Payments, *payment)
+		}
+	}
+
+	// Alert dikirim di background supaya tidak memperlambat atau menggagalkan order
+	go u.alertLowStock(pricing.Items)
+	u.notify(domain.NotificationOrderCreated, createdOrder.ID)
+	u.publish(domain.WebhookOrderCreated, dto.ToWebhookOrder(createdOrder))
+
+	return &domain.CreateOrderResponse{
+		Message: "Order created successfully",
+		Order:   *dto.ToOrderResponse(createdOrder),
+	}, nil
+}
+
+func (u *orderUsecase) QuoteOrder(req domain.QuoteOrderRequest) (*domain.QuoteOrderResponse, error) {
+	pricing, err := u.priceOrder("", pricingInput{
+		FulfilmentMethod: req.FulfilmentMethod,
+		Items:            req.Items,
+		VoucherCode:      req.VoucherCode,
+		Whatsapp:         utils.NormalizeWhatsapp(req.Whatsapp),
+		Latitude:         req.Latitude,
+		Longitude:        req.Longitude,
+		DestinationCity:  req.DestinationCity,
+		Courier:          req.Courier,
+		CourierService:   req.CourierService,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	lines := make([]domain.QuoteLineResponse, len(pricing.Items))
+	for i, item := range pricing.Items {
+		lines[i] = domain.QuoteLineResponse{
+			ProductID: item.ProductID,
+			Name:      item.Product.Name,
+			UnitPrice: item.PriceAtPurchase,
+			Quantity:  item.Quantity,
+			LineTotal: item.PriceAtPurchase * float64(item.Quantity),
+			Available: item.Product.AvailableStock(),
+		}
+	}
+
+	res := &domain.QuoteOrderResponse{
+		Items:         lines,
+		Subtotal:      pricing.Subtotal,
+		DistanceKm:    pricing.DistanceKm,
+		ShippingFee:   pricing.DeliveryFee,
+		DiscountTotal: pricing.DiscountTotal,
+		Discounts:     dto.ToOrderDiscountResponses(pricing.Discounts),
+		TotalPrice:    pricing.Total,
+		VoucherCode:   pricing.VoucherCode,
+		Problems:      pricing.Problems,
+		Orderable:     len(pricing.Problems) == 0 && pricing.VoucherErr == nil && pricing.DeliveryErr == nil,
+	}
+	if pricing.VoucherErr != nil {
+		res.VoucherError = pricing.VoucherErr.Error()
+	}
+	if pricing.DeliveryErr != nil {
+		res.DeliveryError = pricing.DeliveryErr.Error()
+	}
+	if pricing.DeliveryZone != nil {
+		res.DeliveryZone = pricing.DeliveryZone.Name
+	}
+	if pricing.DeliveryDate != nil {
+		deliveryDate := pricing.DeliveryDate.Format("2006-01-02")
+		res.DeliveryDate = &deliveryDate
+	}
+	if req.DestinationCity != "" && u.courier != nil {
+		rates, err := u.courier.GetRates(u.deliveryConfig.OriginCity, req.DestinationCity, pricing.WeightGrams)
+		if err == nil {
+			res.CourierRates = rates
+		}
+	}
+	if res.Problems == nil {
+		res.Problems = []domain.QuoteItemProblem{}
+	}
+	return res, nil
+}
+
+// pricingInput data dari request order/quote yang mempengaruhi harga
+type pricingInput struct {
+	FulfilmentMethod domain.FulfilmentMethod
+	Items            []domain.OrderItemRequest
+	VoucherCode      string
+	Whatsapp         string
+	Latitude         float64
+	Longitude        float64
+	DestinationCity  string
+	Courier          string
+	CourierService   string
+}
+
+// orderPricing hasil kalkulasi cart yang dipakai bersama oleh CreateOrder dan QuoteOrder
+type orderPricing struct {
+	Items         []domain.OrderItem
+	Reservations  []domain.StockReservation
+	Subtotal      float64
+	Discounts     []domain.OrderDiscount
+	DiscountTotal float64
+	DistanceKm    float64
+	DeliveryFee   float64
+	DeliveryZone  *domain.DeliveryZone
+	DeliveryDate  *time.Time
+	CourierRate   *domain.CourierRate
+	WeightGrams   int
+	Total         float64
+	VoucherCode   string
+	VoucherUsage  *domain.VoucherUsage
+	VoucherErr    error
+	DeliveryErr   error
+	Problems      []domain.QuoteItemProblem
+}
+
+// priceOrder validasi product dan stock, lalu hitung promo, voucher dan ongkir.
+// Masalah per item, voucher dan ongkir dikumpulkan, bukan langsung gagal, supaya quote bisa menampilkan semuanya.
+func (u *orderUsecase) priceOrder(orderID string, input pricingInput) (*orderPricing, error) {
+	pricing := &orderPricing{}
+
 	// Validasi semua product dan stock
-	for _, item := range req.Items {
+	for _, item := range input.Items {
 		product, err := u.productRepo.GetProductByID(item.ProductID)
 		if err != nil {
-			return nil, errors.New("product not found")
+			pricing.Problems = append(pricing.Problems, domain.QuoteItemProblem{
+				ProductID: item.ProductID,
+				Requested: item.Quantity,
+				Message:   "product not found",
+			})
+			continue
 		}
 
-		if product.Stock < item.Quantity {
-			return nil, errors.New("stock not enough for product: " + product.Name)
+		if product.AvailableStock() < item.Quantity {
+			pricing.Problems = append(pricing.Problems, domain.QuoteItemProblem{
+				ProductID: item.ProductID,
+				Requested: item.Quantity,
+				Available: product.AvailableStock(),
+				Message:   "stock not enough for product: " + product.Name,
+			})
 		}
 
-		priceAtPurchase := product.Price * float64(item.Quantity)
-		totalPrice += priceAtPurchase
+		pricing.Subtotal += product.Price * float64(item.Quantity)
+		pricing.WeightGrams += product.Weight * item.Quantity
 
-		orderItems = append(orderItems, domain.OrderItem{
+		pricing.Items = append(pricing.Items, domain.OrderItem{
 			OrderID:         orderID,
 			ProductID:       item.ProductID,
 			Product:         *product,
@@ -65,50 +360,298 @@ func (u *orderUsecase) CreateOrder(req domain.CreateOrderRequest, proofOfPayment
 			PriceAtPurchase: product.Price,
 		})
 
-		stockUpdates = append(stockUpdates, struct {
-			ProductID uint
-			NewStock  int
-		}{
+		pricing.Reservations = append(pricing.Reservations, domain.StockReservation{
+			OrderID:   orderID,
 			ProductID: product.ID,
-			NewStock:  product.Stock - item.Quantity,
+			Quantity:  item.Quantity,
+			Status:    domain.StockReservationActive,
 		})
 	}
 
-	order := domain.Order{
-		ID:             orderID,
-		CustomerName:   req.CustomerName,
-		Whatsapp:       req.Whatsapp,
-		MapAddress:     req.MapAddress,
-		Latitude:       req.Latitude,
-		Longitude:      req.Longitude,
-		AddressNote:    req.AddressNote,
-		TotalPrice:     totalPrice,
-		ProofOfPayment: proofOfPayment,
-		Status:         domain.OrderStatusPending,
-		OrderItems:     orderItems,
+	// Promo otomatis
+	promotions, err := u.promotionRepo.GetActivePromotions(time.Now())
+	if err != nil {
+		return nil, err
+	}
+	pricing.Discounts = applyPromotions(promotions, pricing.Items, orderID)
+	for _, discount := range pricing.Discounts {
+		pricing.DiscountTotal += discount.Amount
 	}
 
-	// Create order dengan transaction
-	createdOrder, err := u.orderRepo.CreateOrderWithTransaction(order, stockUpdates)
+	// Voucher
+	if input.VoucherCode != "" {
+		pricing.VoucherErr = u.applyVoucher(pricing, orderID, strings.ToUpper(input.VoucherCode), input.Whatsapp)
+	}
+
+	// Ongkir dihitung setelah diskon karena gratis ongkir berdasarkan total setelah potongan
+	if input.FulfilmentMethod == domain.FulfilmentPickup {
+		if input.Courier != "" {
+			pricing.DeliveryErr = domain.RejectOrder("courier shipping is not available for pickup orders")
+		}
+	} else if input.Courier != "" {
+		pricing.DeliveryErr = u.applyCourierFee(pricing, input)
+	} else {
+		pricing.DeliveryErr = u.applyDeliveryFee(pricing, input.Latitude, input.Longitude)
+	}
+
+	pricing.Total = pricing.Subtotal - pricing.DiscountTotal + pricing.DeliveryFee
+	return pricing, nil
+}
+
+// applyCourierFee ongkir kurir antar kota berdasarkan berat total
+func (u *orderUsecase) applyCourierFee(pricing *orderPricing, input pricingInput) error {
+	if input.DestinationCity == "" {
+		return domain.RejectOrder("destination city is required for courier shipping")
+	}
+	if u.courier == nil {
+		return domain.RejectOrder("courier shipping is not available")
+	}
+
+	rates, err := u.courier.GetRates(u.deliveryConfig.OriginCity, input.DestinationCity, pricing.WeightGrams)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	return &domain.CreateOrderResponse{
-		Message: "Order created successfully",
-		Order:   *dto.ToOrderResponse(createdOrder),
-	}, nil
+	for i := range rates {
+		if strings.EqualFold(rates[i].Courier, input.Courier) && strings.EqualFold(rates[i].Service, input.CourierService) {
+			pricing.CourierRate = &rates[i]
+			pricing.DeliveryFee = rates[i].Fee
+			return nil
+		}
+	}
+	return domain.RejectOrder("courier service not available for this destination")
+}
+
+// applyDeliveryFee, zona pengiriman (jika ada yang aktif) menggantikan perhitungan radius
+func (u *orderUsecase) applyDeliveryFee(pricing *orderPricing, latitude, longitude float64) error {
+	cfg := u.deliveryConfig
+
+	zones, err := u.deliveryZoneRepo.GetActiveDeliveryZones()
+	if err != nil {
+		return err
+	}
+	if len(zones) == 0 && !cfg.OriginConfigured {
+		return nil
+	}
+	if latitude == 0 && longitude == 0 {
+		return domain.RejectOrder("delivery location is required")
+	}
+
+	if cfg.OriginConfigured {
+		distance := utils.HaversineKm(cfg.OriginLatitude, cfg.OriginLongitude, latitude, longitude)
+		pricing.DistanceKm = math.Round(distance*100) / 100
+	}
+
+	var fee float64
+	if len(zones) > 0 {
+		zone := findDeliveryZone(zones, latitude, longitude)
+		if zone == nil {
+			return domain.RejectOrder("delivery location is outside our delivery zones")
+		}
+		deliveryDate := nextDeliveryDate(zone, time.Now().In(cfg.Location))
+		pricing.DeliveryZone = zone
+		pricing.DeliveryDate = &deliveryDate
+		fee = zone.Fee
+	} else {
+		if cfg.MaxRadiusKm > 0 && pricing.DistanceKm > cfg.MaxRadiusKm {
+			return domain.RejectOrder("delivery location is outside our delivery radius")
+		}
+
+		hasRates, err := u.deliveryRateRepo.HasDeliveryRates()
+		if err != nil {
+			return err
+		}
+		if hasRates {
+			rate, err := u.deliveryRateRepo.GetDeliveryRateForDistance(pricing.DistanceKm)
+			if err != nil {
+				return domain.RejectOrder(err.Error())
+			}
+			fee = rate.BaseFee + rate.PerKmFee*math.Ceil(pricing.DistanceKm)
+		} else {
+			// Toko baru set lokasi tapi belum isi tarif, order tetap diterima tanpa ongkir
+			log.Printf("No delivery rates configured, delivery fee set to 0 for distance %.2f km", pricing.DistanceKm)
+		}
+	}
+
+	if cfg.FreeAboveTotal > 0 && pricing.Subtotal-pricing.DiscountTotal >= cfg.FreeAboveTotal {
+		return nil
+	}
+	pricing.DeliveryFee = fee
+	return nil
+}
+
+func (u *orderUsecase) applyVoucher(pricing *orderPricing, orderID, code, whatsapp string) error {
+	voucher, err := u.voucherRepo.GetVoucherByCode(code)
+	if err != nil {
+		return domain.RejectOrder("voucher not found")
+	}
+
+	if err := validateVoucher(voucher, pricing.Subtotal, time.Now()); err != nil {
+		return domain.RejectOrder(err.Error())
+	}
+
+	if voucher.UsageLimitPerWhatsapp > 0 && whatsapp != "" {
+		used, err := u.voucherRepo.CountUsageByWhatsapp(voucher.ID, whatsapp)
+		if err != nil {
+			return err
+		}
+		if used >= voucher.UsageLimitPerWhatsapp {
+			return domain.RejectOrder("voucher usage limit reached for this whatsapp number")
+		}
+	}
+
+	voucherDiscount := calculateVoucherDiscount(voucher, pricing.Items)
+	if voucherDiscount <= 0 {
+		return domain.RejectOrder("voucher is not applicable to the items in this order")
+	}
+	if voucherDiscount > pricing.Subtotal-pricing.DiscountTotal {
+		voucherDiscount = pricing.Subtotal - pricing.DiscountTotal
+	}
+	// Promosi sudah menutup subtotal, voucher tidak dicatat supaya kuota pemakaiannya tidak terpakai
+	if voucherDiscount <= 0 {
+		return domain.RejectOrder("voucher has no effect because the order is already fully discounted")
+	}
+	pricing.DiscountTotal += voucherDiscount
+
+	pricing.VoucherCode = voucher.Code
+	pricing.Discounts = append(pricing.Discounts, domain.OrderDiscount{
+		OrderID:     orderID,
+		Type:        domain.OrderDiscountTypeVoucher,
+		VoucherID:   &voucher.ID,
+		Code:        voucher.Code,
+		Description: voucher.Description,
+		Amount:      voucherDiscount,
+	})
+	pricing.VoucherUsage = &domain.VoucherUsage{
+		VoucherID: voucher.ID,
+		Whatsapp:  whatsapp,
+		Discount:  voucherDiscount,
+	}
+	return nil
 }
 
-func (u *orderUsecase) GetAllOrders(offset, limit int) ([]*domain.OrderResponse, int, error) {
-	orders, total, err := u.orderRepo.GetAllOrders(offset, limit)
+func (u *orderUsecase) GetAllOrders(query domain.OrderListQuery, offset, limit int) ([]*domain.OrderResponse, int, error) {
+	filter, err := u.buildOrderFilter(query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	orders, total, err := u.orderRepo.GetAllOrders(filter, offset, limit)
 	if err != nil {
 		return nil, 0, err
 	}
 	orderResponses := dto.ToOrderResponses(orders)
+	u.attachWhatsappLinks(orderResponses, orders)
 	return orderResponses, total, nil
 }
 
+var (
+	orderExportHeader = []interface{}{"order_id", "order_number", "created_at", "status", "customer_name", "whatsapp", "fulfilment_method", "map_address", "address_note", "items", "subtotal", "discount_total", "delivery_fee", "total_price", "voucher_code"}
+	itemExportHeader  = []interface{}{"order_id", "order_number", "created_at", "status", "customer_name", "whatsapp", "product_id", "product_name", "quantity", "price_at_purchase", "line_total"}
+)
+
+// ExportOrders tulis order sesuai filter ke w sebagai CSV/XLSX, dibaca per batch
+func (u *orderUsecase) ExportOrders(query domain.ExportOrdersQuery, w io.Writer) error {
+	filter, err := u.buildOrderFilter(query.OrderListQuery)
+	if err != nil {
+		return err
+	}
+
+	var table utils.TableWriter
+	if query.Format == "xlsx" {
+		table, err = utils.NewXLSXTableWriter(w, "Orders")
+		if err != nil {
+			return errors.New("failed to create spreadsheet")
+		}
+	} else {
+		table = utils.NewCSVTableWriter(w)
+	}
+
+	perItem := query.Rows == "item"
+	header := orderExportHeader
+	if perItem {
+		header = itemExportHeader
+	}
+	if err := table.WriteRow(header); err != nil {
+		return err
+	}
+
+	err = u.orderRepo.StreamOrders(filter, exportBatchSize, func(orders []domain.Order) error {
+		for _, order := range orders {
+			createdAt := order.CreatedAt.In(u.deliveryConfig.Location).Format("2006-01-02 15:04:05")
+			if !perItem {
+				var itemCount int
+				for _, item := range order.OrderItems {
+					itemCount += item.Quantity
+				}
+				if err := table.WriteRow([]interface{}{
+					order.ID, order.OrderNumber, createdAt, string(order.Status), order.CustomerName, order.Whatsapp, string(order.FulfilmentMethod),
+					order.MapAddress, order.AddressNote, itemCount, order.Subtotal, order.DiscountTotal, order.DeliveryFee,
+					order.TotalPrice, order.VoucherCode,
+				}); err != nil {
+					return err
+				}
+				continue
+			}
+
+			for _, item := range order.OrderItems {
+				if err := table.WriteRow([]interface{}{
+					order.ID, order.OrderNumber, createdAt, string(order.Status), order.CustomerName, order.Whatsapp,
+					item.ProductID, item.Product.Name, item.Quantity, item.PriceAtPurchase,
+					item.PriceAtPurchase * float64(item.Quantity),
+				}); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return table.Close()
+}
+
+// buildOrderFilter tanggal from/to (inklusif) dibaca di timezone toko
+func (u *orderUsecase) buildOrderFilter(query domain.OrderListQuery) (domain.OrderFilter, error) {
+	filter := domain.OrderFilter{
+		Status:       query.Status,
+		CustomerName: strings.TrimSpace(query.CustomerName),
+		Whatsapp:     utils.WhatsappSearchDigits(query.Whatsapp),
+		IDPrefix:     strings.TrimSpace(query.OrderID),
+		NumberPrefix: strings.ToUpper(strings.TrimSpace(query.OrderNumber)),
+		MinTotal:     query.MinTotal,
+		MaxTotal:     query.MaxTotal,
+		ProductID:    query.ProductID,
+		CustomerID:   query.CustomerID,
+		SortBy:       query.SortBy,
+		SortOrder:    query.SortOrder,
+	}
+
+	if query.From != "" {
+		from, err := parseStoreDate(query.From, u.deliveryConfig.Location)
+		if err != nil {
+			return filter, err
+		}
+		filter.CreatedFrom = &from
+	}
+	if query.To != "" {
+		to, err := parseStoreDate(query.To, u.deliveryConfig.Location)
+		if err != nil {
+			return filter, err
+		}
+		before := to.AddDate(0, 0, 1)
+		filter.CreatedBefore = &before
+	}
+	if filter.CreatedFrom != nil && filter.CreatedBefore != nil && !filter.CreatedBefore.After(*filter.CreatedFrom) {
+		return filter, errors.New("to date must not be before from date")
+	}
+	if query.MaxTotal > 0 && query.MinTotal > query.MaxTotal {
+		return filter, errors.New("min_total must not be greater than max_total")
+	}
+	return filter, nil
+}
+
 func (u *orderUsecase) GetOrderByID(id string) (*domain.OrderResponse, error) {
 	order, err := u.orderRepo.GetOrderByID(id)
 	if err != nil {
@@ -118,16 +661,272 @@ func (u *orderUsecase) GetOrderByID(id string) (*domain.OrderResponse, error) {
 }
 
 func (u *orderUsecase) UpdateOrderStatus(id string, req domain.UpdateOrderStatusRequest) (*domain.UpdateOrderStatusResponse, error) {
+	existing, err := u.orderRepo.GetOrderByID(id)
+	if err != nil {
+		return nil, err
+	}
+
 	order, err := u.orderRepo.UpdateOrderStatus(id, req.Status)
 	if err != nil {
 		return nil, err
 	}
+
+	// Customer hanya dikabari saat status benar-benar berubah
+	if existing.Status != order.Status {
+		switch order.Status {
+		case domain.OrderStatusSuccess:
+			u.notify(domain.NotificationPaymentVerified, order.ID)
+		case domain.OrderStatusRejected:
+			u.notify(domain.NotificationOrderRejected, order.ID)
+		}
+		u.publishStatusChanged(existing.Status, order)
+	}
+
 	return &domain.UpdateOrderStatusResponse{
 		Message: "Order status updated successfully",
-		Order:   *dto.ToOrderResponse(order),
+		Order:   *u.adminOrderResponse(order),
 	}, nil
 }
 
+func (u *orderUsecase) AttachAirwayBill(id string, req domain.AttachAirwayBillRequest) (*domain.AttachAirwayBillResponse, error) {
+	order, err := u.orderRepo.GetOrderByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if order.Courier == "" {
+		return nil, errors.New("order is not shipped by courier")
+	}
+
+	// Resi tetap disimpan walaupun kurir belum punya data tracking
+	airwayBill := strings.ToUpper(req.AirwayBill)
+	tracking, err := u.trackShipment(order.Courier, airwayBill)
+	if err != nil && !errors.Is(err, domain.ErrTrackingUnavailable) {
+		return nil, errors.New("failed to get tracking status")
+	}
+	var trackingStatus string
+	if tracking != nil {
+		trackingStatus = tracking.Status
+	}
+
+	previousAirwayBill := order.AirwayBill
+	order, err = u.orderRepo.UpdateOrderTracking(id, airwayBill, trackingStatus)
+	if err != nil {
+		return nil, err
+	}
+	if previousAirwayBill != airwayBill {
+		u.notify(domain.NotificationOrderShipped, order.ID)
+		u.publish(domain.WebhookOrderShipped, dto.ToWebhookOrder(order))
+	}
+
+	return &domain.AttachAirwayBillResponse{
+		Message:  "Airway bill attached successfully",
+		Order:    *u.adminOrderResponse(order),
+		Tracking: tracking,
+	}, nil
+}
+
+func (u *orderUsecase) TrackOrder(id string) (*domain.OrderTrackingResponse, error) {
+	order, err := u.orderRepo.GetOrderByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if order.AirwayBill == "" {
+		return nil, errors.New("order has no airway bill yet")
+	}
+
+	tracking, err := u.trackShipment(order.Courier, order.AirwayBill)
+	if errors.Is(err, domain.ErrTrackingUnavailable) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, errors.New("failed to get tracking status")
+	}
+
+	if tracking.Status != order.TrackingStatus {
+		if _, err := u.orderRepo.UpdateOrderTracking(id, order.AirwayBill, tracking.Status); err != nil {
+			return nil, err
+		}
+	}
+
+	return &domain.OrderTrackingResponse{
+		OrderID:  order.ID,
+		Tracking: tracking,
+	}, nil
+}
+
+// trackShipment tracking dari kurir, ErrTrackingUnavailable jika adapter kurir tidak diatur
+func (u *orderUsecase) trackShipment(courier, airwayBill string) (*domain.TrackingInfo, error) {
+	if u.courier == nil {
+		return nil, domain.ErrTrackingUnavailable
+	}
+	return u.courier.Track(courier, airwayBill)
+}
+
+func (u *orderUsecase) CollectOrder(id string, req domain.CollectOrderRequest) (*domain.CollectOrderResponse, error) {
+	order, err := u.orderRepo.GetOrderByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if order.FulfilmentMethod != domain.FulfilmentPickup {
+		return nil, errors.New("order is not a pickup order")
+	}
+	if !strings.EqualFold(order.PickupCode, req.PickupCode) {
+		return nil, errors.New("invalid pickup code")
+	}
+	if order.Status != domain.OrderStatusSuccess {
+		return nil, errors.New("order payment has not been confirmed")
+	}
+
+	order, err = u.orderRepo.MarkOrderCollected(id, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	u.publish(domain.WebhookOrderCollected, dto.ToWebhookOrder(order))
+
+	return &domain.CollectOrderResponse{
+		Message: "Order marked as collected",
+		Order:   *u.adminOrderResponse(order),
+	}, nil
+}
+
+// GetPickupQRCode PNG berisi order ID dan pickup code untuk discan kasir
+func (u *orderUsecase) GetPickupQRCode(id string) ([]byte, error) {
+	order, err := u.orderRepo.GetOrderByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if order.FulfilmentMethod != domain.FulfilmentPickup {
+		return nil, errors.New("order is not a pickup order")
+	}
+
+	png, err := qrcode.Encode(pickupQRPrefix+order.ID+":"+order.PickupCode, qrcode.Medium, 256)
+	if err != nil {
+		return nil, errors.New("failed to generate pickup QR code")
+	}
+	return png, nil
+}
+
+// GetInvoicePDF invoice (atau receipt untuk order success) dalam bentuk PDF
+func (u *orderUsecase) GetInvoicePDF(id string) ([]byte, error) {
+	order, err := u.orderRepo.GetOrderByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	pdf, err := u.invoices.RenderInvoice(order)
+	if err != nil {
+		return nil, errors.New("failed to generate invoice")
+	}
+	return pdf, nil
+}
+
+// attachWhatsappLinks tambahkan link wa.me untuk tampilan admin, gagal render tidak menggagalkan request
+func (u *orderUsecase) attachWhatsappLinks(responses []*domain.OrderResponse, orders []domain.Order) {
+	links, err := u.notifications.WhatsappLinks(orders)
+	if err != nil {
+		log.Println("Failed to build WhatsApp links:", err)
+		return
+	}
+	for i := range responses {
+		responses[i].WhatsappLinks = links[orders[i].ID]
+	}
+}
+
+// adminOrderResponse response satu order untuk admin, lengkap dengan link WhatsApp
+func (u *orderUsecase) adminOrderResponse(order *domain.Order) *domain.OrderResponse {
+	response := dto.ToOrderResponse(order)
+	u.attachWhatsappLinks([]*domain.OrderResponse{response}, []domain.Order{*order})
+	return response
+}
+
+// notify gagal kirim notifikasi tidak menggagalkan proses order
+func (u *orderUsecase) notify(event domain.NotificationEvent, orderID string) {
+	if err := u.notifications.NotifyOrder(event, orderID); err != nil {
+		log.Printf("Failed to notify %s for order %s: %v", event, orderID, err)
+	}
+}
+
+// publish gagal menyimpan event webhook tidak menggagalkan proses order
+func (u *orderUsecase) publish(event domain.WebhookEvent, data interface{}) {
+	if err := u.webhooks.Publish(event, data); err != nil {
+		log.Printf("Failed to publish webhook %s: %v", event, err)
+	}
+}
+
+func (u *orderUsecase) publishStatusChanged(previous domain.OrderStatus, order *domain.Order) {
+	u.publish(domain.WebhookOrderStatusChanged, domain.WebhookOrderStatusData{
+		PreviousStatus: previous,
+		Order:          *dto.ToWebhookOrder(order),
+	})
+}
+
+// alertLowStock kirim alert untuk product yang baru saja turun ke bawah threshold karena order ini
+func (u *orderUsecase) alertLowStock(items []domain.OrderItem) {
+	for _, item := range items {
+		threshold := item.Product.LowStockLimit(u.lowStockThreshold)
+		before := item.Product.AvailableStock()
+		after := before - item.Quantity
+		if before <= threshold || after > threshold {
+			continue
+		}
+
+		subject := "Low stock"
+		if after <= 0 {
+			subject = "Out of stock"
+		}
+		message := fmt.Sprintf("%s (ID %d) has %d available, threshold %d", item.Product.Name, item.ProductID, after, threshold)
+		if err := u.alerts.SendAlert(subject, message); err != nil {
+			log.Printf("failed to send low stock alert: %v", err)
+		}
+
+		status := domain.LowStockStatusLow
+		if after <= 0 {
+			status = domain.LowStockStatusOutOfStock
+		}
+		u.publish(domain.WebhookProductLowStock, domain.WebhookLowStockData{
+			ProductID: item.ProductID,
+			Name:      item.Product.Name,
+			Available: after,
+			Threshold: threshold,
+			Status:    status,
+		})
+	}
+}
+
+// CancelStalePendingOrders batalkan order pending yang lebih lama dari maxAge
+func (u *orderUsecase) CancelStalePendingOrders(maxAge time.Duration) (int, error) {
+	now := time.Now()
+	ids, err := u.orderRepo.GetStalePendingOrderIDs(now.Add(-maxAge), now, staleOrderBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	// Durasi di bawah satu jam atau tidak bulat ditulis apa adanya, bukan "0 hours"
+	within := maxAge.String()
+	if maxAge >= time.Hour && maxAge%time.Hour == 0 {
+		within = fmt.Sprintf("%d hours", int(maxAge.Hours()))
+	}
+	reason := "payment not confirmed within " + within
+	var cancelled int
+	for _, id := range ids {
+		ok, err := u.orderRepo.CancelPendingOrder(id, reason, now)
+		if err != nil {
+			return cancelled, err
+		}
+		if ok {
+			cancelled++
+			if order, err := u.orderRepo.GetOrderByID(id); err == nil {
+				u.publishStatusChanged(domain.OrderStatusPending, order)
+			}
+		}
+	}
+	return cancelled, nil
+}
+
+func (u *orderUsecase) ReleaseExpiredReservations() (int, error) {
+	return u.reservationRepo.ReleaseExpiredReservations(time.Now())
+}
+
 func (u *orderUsecase) DeleteOrder(id string) error {
 	return u.orderRepo.DeleteOrder(id)
 }
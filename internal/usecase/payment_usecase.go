@@ -0,0 +1,273 @@
+package usecase
+
+import (
+	"butik/internal/domain"
+	"butik/internal/domain/dto"
+	"butik/internal/infrastructure"
+	"butik/internal/repository"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"time"
+
+	gonanoid "github.com/matoous/go-nanoid/v2"
+)
+
+const (
+	paymentReferenceAlphabet = "0123456789ABCDEFGHJKLMNPQRSTUVWXYZ"
+	paymentSyncBatchSize     = 50
+	// paymentSyncDelay tagihan baru dicek ke provider setelah jeda ini, biasanya callback sudah datang
+	paymentSyncDelay = 5 * time.Minute
+	// paymentExpiryGrace tagihan yang tidak dilaporkan kadaluarsa oleh provider ditutup setelah jeda ini
+	paymentExpiryGrace = time.Hour
+)
+
+type PaymentUsecase interface {
+	Enabled() bool
+	CreateCharge(order *domain.Order) (*domain.Payment, error)
+	CreatePayment(orderID string) (*domain.CreatePaymentResponse, error)
+	HandleCallback(header http.Header, body []byte) (*domain.PaymentCallbackResponse, error)
+	SimulatePayment(reference string, req domain.SimulatePaymentRequest) (*domain.PaymentCallbackResponse, error)
+	SyncPendingPayments() (int, error)
+}
+
+type paymentUsecase struct {
+	paymentRepo   repository.PaymentRepo
+	orderRepo     repository.OrderRepo
+	provider      infrastructure.PaymentProvider
+	expiry        time.Duration
+	notifications NotificationUsecase
+	webhooks      WebhookUsecase
+	alerts        infrastructure.AlertNotifier
+}
+
+func NewPaymentUsecase(paymentRepo repository.PaymentRepo, orderRepo repository.OrderRepo, provider infrastructure.PaymentProvider, expiry time.Duration, notifications NotificationUsecase, webhooks WebhookUsecase, alerts infrastructure.AlertNotifier) PaymentUsecase {
+	return &paymentUsecase{
+		paymentRepo:   paymentRepo,
+		orderRepo:     orderRepo,
+		provider:      provider,
+		expiry:        expiry,
+		notifications: notifications,
+		webhooks:      webhooks,
+		alerts:        alerts,
+	}
+}
+
+// Enabled false jika PAYMENT_PROVIDER tidak diatur, order hanya bisa transfer manual
+func (u *paymentUsecase) Enabled() bool {
+	return u.provider != nil
+}
+
+// CreateCharge buat tagihan di provider, tagihan pending yang belum kadaluarsa dipakai ulang
+func (u *paymentUsecase) CreateCharge(order *domain.Order) (*domain.Payment, error) {
+	if u.provider == nil {
+		return nil, domain.ErrPaymentGatewayUnavailable
+	}
+
+	open, err := u.paymentRepo.GetOpenPayment(order.ID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if open != nil {
+		return open, nil
+	}
+
+	id, err := gonanoid.Generate(paymentReferenceAlphabet, 16)
+	if err != nil {
+		return nil, errors.New("failed to generate payment reference")
+	}
+	reference := "PAY-" + id
+
+	charge, err := u.provider.CreateCharge(infrastructure.ChargeRequest{
+		Reference:    reference,
+		Amount:       paymentAmount(order.TotalPrice),
+		CustomerName: order.CustomerName,
+		Email:        order.Email,
+		Phone:        order.Whatsapp,
+		Expiry:       u.expiry,
+	})
+	if err != nil {
+		log.Printf("Failed to create %s charge for order %s: %v", u.provider.Name(), order.ID, err)
+		return nil, domain.ErrPaymentFailed
+	}
+
+	return u.paymentRepo.CreatePayment(domain.Payment{
+		OrderID:    order.ID,
+		Provider:   u.provider.Name(),
+		Reference:  reference,
+		Amount:     order.TotalPrice,
+		Status:     domain.PaymentStatusPending,
+		PaymentURL: charge.PaymentURL,
+		ExpiresAt:  charge.ExpiresAt,
+	})
+}
+
+// CreatePayment tagihan untuk order gateway yang belum dibayar, misalnya setelah tagihan sebelumnya kadaluarsa
+func (u *paymentUsecase) CreatePayment(orderID string) (*domain.CreatePaymentResponse, error) {
+	order, err := u.orderRepo.GetOrderByID(orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.PaymentMethod != domain.PaymentMethodGateway {
+		return nil, errors.New("order is paid by manual transfer")
+	}
+	if order.Status != domain.OrderStatusPending {
+		return nil, errors.New("order is no longer awaiting payment")
+	}
+
+	payment, err := u.CreateCharge(order)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.CreatePaymentResponse{
+		Message: "Payment created successfully",
+		Payment: *dto.ToPaymentResponse(payment),
+	}, nil
+}
+
+// HandleCallback proses notifikasi dari provider, callback yang sama boleh datang berkali-kali
+func (u *paymentUsecase) HandleCallback(header http.Header, body []byte) (*domain.PaymentCallbackResponse, error) {
+	if u.provider == nil {
+		return nil, domain.ErrPaymentGatewayUnavailable
+	}
+
+	state, err := u.provider.VerifyCallback(header, body)
+	if err != nil {
+		return nil, err
+	}
+
+	payment, err := u.paymentRepo.GetPaymentByReference(state.Reference)
+	if err != nil {
+		return nil, err
+	}
+	if payment.Provider != u.provider.Name() {
+		return nil, domain.ErrPaymentNotFound
+	}
+
+	if err := u.apply(payment, state); err != nil {
+		return nil, err
+	}
+	return &domain.PaymentCallbackResponse{Message: "Payment callback processed"}, nil
+}
+
+// SimulatePayment bayar, gagalkan, atau kadaluarsakan tagihan fake lewat jalur callback yang sama
+func (u *paymentUsecase) SimulatePayment(reference string, req domain.SimulatePaymentRequest) (*domain.PaymentCallbackResponse, error) {
+	fake, ok := u.provider.(*infrastructure.FakePaymentProvider)
+	if !ok {
+		return nil, domain.RejectPayment("payment simulation is only available with the fake provider")
+	}
+
+	payment, err := u.paymentRepo.GetPaymentByReference(reference)
+	if err != nil {
+		return nil, err
+	}
+
+	header, body, err := fake.Complete(payment.Reference, req.Status, paymentAmount(payment.Amount))
+	if err != nil {
+		return nil, err
+	}
+	return u.HandleCallback(header, body)
+}
+
+// SyncPendingPayments cek status tagihan pending ke provider untuk callback yang hilang
+func (u *paymentUsecase) SyncPendingPayments() (int, error) {
+	if u.provider == nil {
+		return 0, nil
+	}
+
+	now := time.Now()
+	payments, err := u.paymentRepo.GetPendingPayments(now.Add(-paymentSyncDelay), paymentSyncBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	updated := 0
+	for i := range payments {
+		payment := &payments[i]
+		if payment.Provider != u.provider.Name() {
+			continue
+		}
+
+		state, err := u.provider.GetStatus(payment.Reference)
+		if err != nil {
+			log.Printf("Failed to get status of payment %s: %v", payment.Reference, err)
+			continue
+		}
+		if state.Status == domain.PaymentStatusPending && payment.ExpiresAt != nil && now.After(payment.ExpiresAt.Add(paymentExpiryGrace)) {
+			state.Status = domain.PaymentStatusExpired
+		}
+		if state.Status == domain.PaymentStatusPending {
+			continue
+		}
+
+		if err := u.apply(payment, state); err != nil {
+			log.Printf("Failed to update payment %s: %v", payment.Reference, err)
+			continue
+		}
+		updated++
+	}
+	return updated, nil
+}
+
+// apply simpan status tagihan, order pending otomatis success setelah lunas
+func (u *paymentUsecase) apply(payment *domain.Payment, state *infrastructure.PaymentState) error {
+	switch state.Status {
+	case domain.PaymentStatusPaid:
+		if state.Amount != paymentAmount(payment.Amount) {
+			u.alert("Payment amount mismatch", fmt.Sprintf("Payment %s for order %s paid %d, expected %d", payment.Reference, payment.OrderID, state.Amount, paymentAmount(payment.Amount)))
+			return domain.RejectPayment("payment amount mismatch")
+		}
+
+		settled, confirmed, err := u.paymentRepo.SettlePayment(payment.ID, time.Now())
+		if err != nil {
+			return err
+		}
+		if !settled {
+			return nil
+		}
+		if !confirmed {
+			// Order sudah dibatalkan/ditolak atau stok habis, uang perlu dikembalikan atau order diproses manual
+			u.alert("Payment for closed order", fmt.Sprintf("Payment %s for order %s was received but the order could not be confirmed", payment.Reference, payment.OrderID))
+			return nil
+		}
+		u.orderPaid(payment.OrderID)
+	case domain.PaymentStatusFailed, domain.PaymentStatusExpired:
+		if _, err := u.paymentRepo.UpdatePendingPayment(payment.ID, state.Status); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// orderPaid kabari customer dan integrasi seperti saat admin memverifikasi pembayaran
+func (u *paymentUsecase) orderPaid(orderID string) {
+	if err := u.notifications.NotifyOrder(domain.NotificationPaymentVerified, orderID); err != nil {
+		log.Printf("Failed to notify %s for order %s: %v", domain.NotificationPaymentVerified, orderID, err)
+	}
+
+	order, err := u.orderRepo.GetOrderByID(orderID)
+	if err != nil {
+		log.Printf("Failed to publish webhook %s: %v", domain.WebhookOrderStatusChanged, err)
+		return
+	}
+	err = u.webhooks.Publish(domain.WebhookOrderStatusChanged, domain.WebhookOrderStatusData{
+		PreviousStatus: domain.OrderStatusPending,
+		Order:          *dto.ToWebhookOrder(order),
+	})
+	if err != nil {
+		log.Printf("Failed to publish webhook %s: %v", domain.WebhookOrderStatusChanged, err)
+	}
+}
+
+func (u *paymentUsecase) alert(subject, message string) {
+	if err := u.alerts.SendAlert(subject, message); err != nil {
+		log.Printf("failed to send payment alert: %v", err)
+	}
+}
+
+// paymentAmount nominal rupiah bulat yang dikirim ke provider
+func paymentAmount(total float64) int64 {
+	return int64(math.Round(total))
+}
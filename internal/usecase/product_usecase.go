@@ -5,31 +5,41 @@ import (
 	"butik/internal/domain/dto"
 	"butik/internal/repository"
 	"errors"
+	"log"
 	"os"
 )
 
 type ProductUsecase interface {
-	CreateProduct(req domain.CreateProductRequest, imageURL string) (*domain.CreateProductResponse, error)
+	CreateProduct(req domain.CreateProductRequest, imageURL string, userID *uint) (*domain.CreateProductResponse, error)
 	GetAllProducts(offset, limit int) ([]*domain.ProductResponse, int, error)
 	GetProductByID(id uint) (*domain.ProductResponse, error)
 	UpdateProduct(id uint, req domain.UpdateProductRequest, imageURL string) (*domain.UpdateProductResponse, error)
 	DeleteProduct(id uint) (*domain.DeleteProductResponse, error)
 	ReduceStock(productID uint, qty int) error
+	GetStockHistory(productID uint, offset, limit int) (*domain.StockHistoryResponse, int, error)
+	CheckStockLedger() (int, error)
+	AdjustStock(productID uint, req domain.AdjustStockRequest, userID *uint) (*domain.AdjustStockResponse, error)
+	BulkRestock(req domain.BulkRestockRequest, userID *uint) (*domain.BulkRestockResponse, error)
+	GetLowStockProducts(offset, limit int) ([]*domain.LowStockProductResponse, int, error)
 }
 
 type productUsecase struct {
-	productRepo  repository.ProductRepo
-	categoryRepo repository.CategoryRepo
+	productRepo       repository.ProductRepo
+	categoryRepo      repository.CategoryRepo
+	stockMovementRepo repository.StockMovementRepo
+	lowStockThreshold int
 }
 
-func NewProductUsecase(productRepo repository.ProductRepo, categoryRepo repository.CategoryRepo) ProductUsecase {
+func NewProductUsecase(productRepo repository.ProductRepo, categoryRepo repository.CategoryRepo, stockMovementRepo repository.StockMovementRepo, lowStockThreshold int) ProductUsecase {
 	return &productUsecase{
-		productRepo:  productRepo,
-		categoryRepo: categoryRepo,
+		productRepo:       productRepo,
+		categoryRepo:      categoryRepo,
+		stockMovementRepo: stockMovementRepo,
+		lowStockThreshold: lowStockThreshold,
 	}
 }
 
-func (u *productUsecase) CreateProduct(req domain.CreateProductRequest, imageURL string) (*domain.CreateProductResponse, error) {
+func (u *productUsecase) CreateProduct(req domain.CreateProductRequest, imageURL string, userID *uint) (*domain.CreateProductResponse, error) {
 	// Validasi category
 	category, err := u.categoryRepo.GetCategoryByID(req.CategoryID)
 	if err != nil {
@@ -37,16 +47,18 @@ func (u *productUsecase) CreateProduct(req domain.CreateProductRequest, imageURL
 	}
 
 	product := domain.Product{
-		Name:        req.Name,
-		Description: req.Description,
-		Price:       req.Price,
-		Stock:       req.Stock,
-		CategoryID:  req.CategoryID,
-		Category:    *category,
-		ImageURL:    imageURL,
+		Name:              req.Name,
+		Description:       req.Description,
+		Price:             req.Price,
+		Stock:             req.Stock,
+		Weight:            req.Weight,
+		LowStockThreshold: req.LowStockThreshold,
+		CategoryID:        req.CategoryID,
+		Category:          *category,
+		ImageURL:          imageURL,
 	}
 
-	createdProduct, err := u.productRepo.CreateProduct(product)
+	createdProduct, err := u.productRepo.CreateProduct(product, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -99,13 +111,14 @@ func (u *productUsecase) UpdateProduct(id uint, req domain.UpdateProductRequest,
 	}
 
 	product := domain.Product{
-		Name:        req.Name,
-		Description: req.Description,
-		Price:       req.Price,
-		Stock:       req.Stock,
-		CategoryID:  req.CategoryID,
-		Category:    *category,
-		ImageURL:    imageURL,
+		Name:              req.Name,
+		Description:       req.Description,
+		Price:             req.Price,
+		Weight:            req.Weight,
+		LowStockThreshold: req.LowStockThreshold,
+		CategoryID:        req.CategoryID,
+		Category:          *category,
+		ImageURL:          imageURL,
 	}
 
 	updatedProduct, err := u.productRepo.UpdateProduct(id, product)
@@ -145,16 +158,140 @@ func (u *productUsecase) DeleteProduct(id uint) (*domain.DeleteProductResponse,
 }
 
 func (u *productUsecase) ReduceStock(productID uint, qty int) error {
+	_, err := u.stockMovementRepo.RecordMovement(domain.StockMovement{
+		ProductID: productID,
+		Delta:     -qty,
+		Reason:    domain.StockMovementSale,
+	})
+	return err
+}
+
+func (u *productUsecase) GetStockHistory(productID uint, offset, limit int) (*domain.StockHistoryResponse, int, error) {
 	product, err := u.productRepo.GetProductByID(productID)
 	if err != nil {
-		return err
+		return nil, 0, err
+	}
+
+	movements, total, err := u.stockMovementRepo.GetMovementsByProductID(productID, offset, limit)
+	if err != nil {
+		return nil, 0, err
 	}
-	if product.Stock < qty {
-		return errors.New("stock not enough")
+
+	ledgerStock, err := u.stockMovementRepo.GetLedgerStock(productID)
+	if err != nil {
+		return nil, 0, err
 	}
-	product.Stock -= qty
-	_, err = u.productRepo.UpdateProduct(productID, *product)
-	return err
+
+	return &domain.StockHistoryResponse{
+		Product:     *dto.ToProductResponse(product),
+		LedgerStock: ledgerStock,
+		Movements:   dto.ToStockMovementResponses(movements),
+	}, total, nil
+}
+
+func (u *productUsecase) AdjustStock(productID uint, req domain.AdjustStockRequest, userID *uint) (*domain.AdjustStockResponse, error) {
+	// Restock dan return selalu menambah stok
+	if req.Reason != domain.StockMovementAdjustment && req.Delta < 0 {
+		return nil, errors.New("delta must be positive for " + string(req.Reason))
+	}
+
+	if _, err := u.productRepo.GetProductByID(productID); err != nil {
+		return nil, err
+	}
+
+	movement, err := u.stockMovementRepo.RecordMovement(domain.StockMovement{
+		ProductID: productID,
+		Delta:     req.Delta,
+		Reason:    req.Reason,
+		UserID:    userID,
+		Note:      req.Note,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	product, err := u.productRepo.GetProductByID(productID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.AdjustStockResponse{
+		Message:  "Stock adjusted successfully",
+		Product:  *dto.ToProductResponse(product),
+		Movement: *dto.ToStockMovementResponse(movement),
+	}, nil
+}
+
+func (u *productUsecase) BulkRestock(req domain.BulkRestockRequest, userID *uint) (*domain.BulkRestockResponse, error) {
+	seen := make(map[uint]bool, len(req.Items))
+	movements := make([]domain.StockMovement, 0, len(req.Items))
+	for _, item := range req.Items {
+		if seen[item.ProductID] {
+			return nil, errors.New("duplicate product_id in items")
+		}
+		seen[item.ProductID] = true
+
+		if _, err := u.productRepo.GetProductByID(item.ProductID); err != nil {
+			return nil, err
+		}
+
+		movements = append(movements, domain.StockMovement{
+			ProductID: item.ProductID,
+			Delta:     item.Quantity,
+			Reason:    domain.StockMovementRestock,
+			UserID:    userID,
+			Note:      req.Note,
+		})
+	}
+
+	recorded, err := u.stockMovementRepo.RecordMovements(movements)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*domain.StockMovementResponse, len(recorded))
+	for i := range recorded {
+		responses[i] = dto.ToStockMovementResponse(&recorded[i])
+	}
+
+	return &domain.BulkRestockResponse{
+		Message:   "Products restocked successfully",
+		Movements: responses,
+	}, nil
+}
+
+func (u *productUsecase) GetLowStockProducts(offset, limit int) ([]*domain.LowStockProductResponse, int, error) {
+	products, total, err := u.productRepo.GetLowStockProducts(u.lowStockThreshold, offset, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	responses := make([]*domain.LowStockProductResponse, len(products))
+	for i := range products {
+		status := domain.LowStockStatusLow
+		if products[i].AvailableStock() <= 0 {
+			status = domain.LowStockStatusOutOfStock
+		}
+		responses[i] = &domain.LowStockProductResponse{
+			Product:   *dto.ToProductResponse(&products[i]),
+			Threshold: products[i].LowStockLimit(u.lowStockThreshold),
+			Status:    status,
+		}
+	}
+	return responses, total, nil
+}
+
+// CheckStockLedger laporkan product yang stoknya berbeda dengan total ledger.
+// Stok tidak diubah otomatis, selisih harus diperiksa admin lalu dikoreksi lewat adjustment.
+func (u *productUsecase) CheckStockLedger() (int, error) {
+	drifts, err := u.stockMovementRepo.GetStockDrifts()
+	if err != nil {
+		return 0, err
+	}
+	for _, drift := range drifts {
+		log.Printf("Stock drift on product %d (%s): stock %d, ledger %d", drift.ProductID, drift.Name, drift.Stock, drift.LedgerStock)
+	}
+	return len(drifts), nil
 }
 
 // Helper untuk hapus file
@@ -0,0 +1,174 @@
+package usecase
+
+import (
+	"butik/internal/domain"
+	"sort"
+)
+
+// applyPromotions evaluasi promo aktif (urut priority tertinggi) terhadap isi cart.
+// Promo non-stackable hanya berlaku sendirian: dilewati jika sudah ada promo lain,
+// dan menghentikan evaluasi jika berhasil diterapkan.
+func applyPromotions(promotions []domain.Promotion, items []domain.OrderItem, orderID string) []domain.OrderDiscount {
+	var discounts []domain.OrderDiscount
+	var remaining float64
+	for _, item := range items {
+		remaining += item.PriceAtPurchase * float64(item.Quantity)
+	}
+
+	for i := range promotions {
+		promo := &promotions[i]
+		if !promo.Stackable && len(discounts) > 0 {
+			continue
+		}
+
+		amount := evaluatePromotion(promo, items)
+		if amount > remaining {
+			amount = remaining
+		}
+		if amount <= 0 {
+			continue
+		}
+
+		remaining -= amount
+		discounts = append(discounts, domain.OrderDiscount{
+			OrderID:     orderID,
+			Type:        domain.OrderDiscountTypePromotion,
+			PromotionID: &promo.ID,
+			Code:        promo.Name,
+			Description: promo.Description,
+			Amount:      amount,
+		})
+
+		if !promo.Stackable {
+			break
+		}
+	}
+
+	return discounts
+}
+
+// evaluatePromotion hitung potongan satu promo, 0 jika syarat tidak terpenuhi
+func evaluatePromotion(promo *domain.Promotion, items []domain.OrderItem) float64 {
+	var eligibleQty int
+	var eligibleSubtotal float64
+	var eligibleUnitPrices []float64
+	for _, item := range items {
+		if promo.Type == domain.PromotionTypeFreeProduct && promo.RewardProductID != nil && item.ProductID == *promo.RewardProductID {
+			continue
+		}
+		if !productInScope(promo.Categories, promo.Products, &item.Product) {
+			continue
+		}
+		eligibleQty += item.Quantity
+		eligibleSubtotal += item.PriceAtPurchase * float64(item.Quantity)
+		for q := 0; q < item.Quantity; q++ {
+			eligibleUnitPrices = append(eligibleUnitPrices, item.PriceAtPurchase)
+		}
+	}
+
+	if eligibleQty == 0 {
+		return 0
+	}
+	if promo.MinQuantity > 0 && eligibleQty < promo.MinQuantity {
+		return 0
+	}
+	if eligibleSubtotal < promo.MinSubtotal {
+		return 0
+	}
+
+	var discount float64
+	switch promo.Type {
+	case domain.PromotionTypePercentage:
+		discount = eligibleSubtotal * promo.DiscountValue / 100
+	case domain.PromotionTypeFixed:
+		discount = promo.DiscountValue
+		if discount > eligibleSubtotal {
+			discount = eligibleSubtotal
+		}
+	case domain.PromotionTypeBuyXGetY:
+		discount = buyXGetYDiscount(promo, eligibleUnitPrices)
+	case domain.PromotionTypeFreeProduct:
+		discount = freeProductDiscount(promo, items, eligibleQty)
+	case domain.PromotionTypeBundle:
+		discount = bundleDiscount(promo, items)
+	}
+
+	if promo.MaxDiscount > 0 && discount > promo.MaxDiscount {
+		discount = promo.MaxDiscount
+	}
+	return discount
+}
+
+// buyXGetYDiscount, setiap kelipatan (buy+get) unit, unit termurah sebanyak get gratis
+func buyXGetYDiscount(promo *domain.Promotion, unitPrices []float64) float64 {
+	groupSize := promo.BuyQuantity + promo.GetQuantity
+	if groupSize <= 0 {
+		return 0
+	}
+
+	freeUnits := (len(unitPrices) / groupSize) * promo.GetQuantity
+	sort.Float64s(unitPrices)
+
+	var discount float64
+	for i := 0; i < freeUnits && i < len(unitPrices); i++ {
+		discount += unitPrices[i]
+	}
+	return discount
+}
+
+// freeProductDiscount, reward product harus ada di cart supaya stok ikut terpotong
+func freeProductDiscount(promo *domain.Promotion, items []domain.OrderItem, eligibleQty int) float64 {
+	if promo.RewardProductID == nil {
+		return 0
+	}
+
+	times := 1
+	if promo.MinQuantity > 0 {
+		times = eligibleQty / promo.MinQuantity
+	}
+
+	freeUnits := times * promo.RewardQuantity
+	for _, item := range items {
+		if item.ProductID != *promo.RewardProductID {
+			continue
+		}
+		if freeUnits > item.Quantity {
+			freeUnits = item.Quantity
+		}
+		return float64(freeUnits) * item.PriceAtPurchase
+	}
+	return 0
+}
+
+// bundleDiscount, satu set = satu unit dari setiap product bundle dengan harga bundle_price
+func bundleDiscount(promo *domain.Promotion, items []domain.OrderItem) float64 {
+	if len(promo.Products) == 0 {
+		return 0
+	}
+
+	sets := -1
+	var setPrice float64
+	for _, prod := range promo.Products {
+		found := false
+		for _, item := range items {
+			if item.ProductID != prod.ID {
+				continue
+			}
+			found = true
+			if sets == -1 || item.Quantity < sets {
+				sets = item.Quantity
+			}
+			setPrice += item.PriceAtPurchase
+			break
+		}
+		if !found {
+			return 0
+		}
+	}
+
+	saving := setPrice - promo.BundlePrice
+	if saving <= 0 {
+		return 0
+	}
+	return float64(sets) * saving
+}
@@ -0,0 +1,205 @@
+package usecase
+
+import (
+	"butik/internal/domain"
+	"testing"
+)
+
+func promoItem(productID, categoryID uint, qty int, price float64) domain.OrderItem {
+	return domain.OrderItem{
+		ProductID:       productID,
+		Product:         domain.Product{ID: productID, CategoryID: categoryID},
+		Quantity:        qty,
+		PriceAtPurchase: price,
+	}
+}
+
+func uintPtr(v uint) *uint { return &v }
+
+func TestEvaluatePromotion(t *testing.T) {
+	// Product 1 dan 2 kategori 10, product 3 kategori 20
+	items := []domain.OrderItem{
+		promoItem(1, 10, 2, 100000),
+		promoItem(2, 10, 1, 50000),
+		promoItem(3, 20, 3, 20000),
+	}
+
+	tests := []struct {
+		name  string
+		promo domain.Promotion
+		items []domain.OrderItem
+		want  float64
+	}{
+		{
+			name:  "persen seluruh cart",
+			promo: domain.Promotion{Type: domain.PromotionTypePercentage, DiscountValue: 10},
+			items: items,
+			want:  31000,
+		},
+		{
+			name:  "persen dibatasi max discount",
+			promo: domain.Promotion{Type: domain.PromotionTypePercentage, DiscountValue: 50, MaxDiscount: 40000},
+			items: items,
+			want:  40000,
+		},
+		{
+			name:  "persen hanya kategori",
+			promo: domain.Promotion{Type: domain.PromotionTypePercentage, DiscountValue: 10, Categories: []domain.Category{{ID: 20}}},
+			items: items,
+			want:  6000,
+		},
+		{
+			name:  "fixed tidak melebihi subtotal eligible",
+			promo: domain.Promotion{Type: domain.PromotionTypeFixed, DiscountValue: 100000, Products: []domain.Product{{ID: 3}}},
+			items: items,
+			want:  60000,
+		},
+		{
+			name:  "min quantity tidak terpenuhi",
+			promo: domain.Promotion{Type: domain.PromotionTypeFixed, DiscountValue: 5000, MinQuantity: 4, Categories: []domain.Category{{ID: 10}}},
+			items: items,
+			want:  0,
+		},
+		{
+			name:  "min subtotal tidak terpenuhi",
+			promo: domain.Promotion{Type: domain.PromotionTypeFixed, DiscountValue: 5000, MinSubtotal: 500000},
+			items: items,
+			want:  0,
+		},
+		{
+			name:  "tidak ada item dalam scope",
+			promo: domain.Promotion{Type: domain.PromotionTypeFixed, DiscountValue: 5000, Categories: []domain.Category{{ID: 99}}},
+			items: items,
+			want:  0,
+		},
+		{
+			name:  "beli 2 gratis 1, unit termurah gratis",
+			promo: domain.Promotion{Type: domain.PromotionTypeBuyXGetY, BuyQuantity: 2, GetQuantity: 1},
+			items: items,
+			want:  40000,
+		},
+		{
+			name:  "beli x gratis y tanpa kelipatan penuh",
+			promo: domain.Promotion{Type: domain.PromotionTypeBuyXGetY, BuyQuantity: 3, GetQuantity: 1, Products: []domain.Product{{ID: 1}}},
+			items: items,
+			want:  0,
+		},
+		{
+			name:  "gratis product per kelipatan min quantity",
+			promo: domain.Promotion{Type: domain.PromotionTypeFreeProduct, MinQuantity: 2, RewardProductID: uintPtr(3), RewardQuantity: 1, Categories: []domain.Category{{ID: 10}}},
+			items: items,
+			want:  20000,
+		},
+		{
+			name:  "gratis product dibatasi jumlah di cart",
+			promo: domain.Promotion{Type: domain.PromotionTypeFreeProduct, MinQuantity: 1, RewardProductID: uintPtr(2), RewardQuantity: 5},
+			items: items,
+			want:  50000,
+		},
+		{
+			name:  "gratis product tidak ada di cart",
+			promo: domain.Promotion{Type: domain.PromotionTypeFreeProduct, RewardProductID: uintPtr(9), RewardQuantity: 1},
+			items: items,
+			want:  0,
+		},
+		{
+			name:  "bundle dihitung per set lengkap",
+			promo: domain.Promotion{Type: domain.PromotionTypeBundle, BundlePrice: 130000, Products: []domain.Product{{ID: 1}, {ID: 2}}},
+			items: items,
+			want:  20000,
+		},
+		{
+			name:  "bundle tidak lengkap",
+			promo: domain.Promotion{Type: domain.PromotionTypeBundle, BundlePrice: 100000, Products: []domain.Product{{ID: 1}, {ID: 9}}},
+			items: items,
+			want:  0,
+		},
+		{
+			name:  "bundle lebih mahal dari harga normal",
+			promo: domain.Promotion{Type: domain.PromotionTypeBundle, BundlePrice: 200000, Products: []domain.Product{{ID: 1}, {ID: 2}}},
+			items: items,
+			want:  0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := evaluatePromotion(&tt.promo, tt.items); got != tt.want {
+				t.Errorf("evaluatePromotion = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyPromotions(t *testing.T) {
+	items := []domain.OrderItem{promoItem(1, 10, 2, 100000)}
+
+	percent := func(id uint, value float64, stackable bool) domain.Promotion {
+		return domain.Promotion{ID: id, Type: domain.PromotionTypePercentage, DiscountValue: value, Stackable: stackable}
+	}
+	fixed := func(id uint, value float64, stackable bool) domain.Promotion {
+		return domain.Promotion{ID: id, Type: domain.PromotionTypeFixed, DiscountValue: value, Stackable: stackable}
+	}
+
+	tests := []struct {
+		name       string
+		promotions []domain.Promotion
+		wantIDs    []uint
+		wantAmount []float64
+	}{
+		{
+			name:       "stackable semua diterapkan",
+			promotions: []domain.Promotion{percent(1, 10, true), fixed(2, 15000, true)},
+			wantIDs:    []uint{1, 2},
+			wantAmount: []float64{20000, 15000},
+		},
+		{
+			name:       "non-stackable pertama menghentikan evaluasi",
+			promotions: []domain.Promotion{percent(1, 10, false), fixed(2, 15000, true)},
+			wantIDs:    []uint{1},
+			wantAmount: []float64{20000},
+		},
+		{
+			name:       "non-stackable dilewati jika sudah ada promo",
+			promotions: []domain.Promotion{fixed(1, 15000, true), percent(2, 50, false), fixed(3, 5000, true)},
+			wantIDs:    []uint{1, 3},
+			wantAmount: []float64{15000, 5000},
+		},
+		{
+			name:       "promo yang tidak berlaku tidak menghalangi non-stackable",
+			promotions: []domain.Promotion{fixed(1, 0, true), percent(2, 10, false)},
+			wantIDs:    []uint{2},
+			wantAmount: []float64{20000},
+		},
+		{
+			name:       "total potongan tidak melebihi subtotal",
+			promotions: []domain.Promotion{fixed(1, 150000, true), fixed(2, 150000, true), fixed(3, 10000, true)},
+			wantIDs:    []uint{1, 2},
+			wantAmount: []float64{150000, 50000},
+		},
+		{
+			name:       "tanpa promo",
+			promotions: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			discounts := applyPromotions(tt.promotions, items, "order-1")
+			if len(discounts) != len(tt.wantIDs) {
+				t.Fatalf("applyPromotions returned %d discounts, want %d", len(discounts), len(tt.wantIDs))
+			}
+			for i, discount := range discounts {
+				if discount.PromotionID == nil || *discount.PromotionID != tt.wantIDs[i] {
+					t.Errorf("discount %d promotion = %v, want %d", i, discount.PromotionID, tt.wantIDs[i])
+				}
+				if discount.Amount != tt.wantAmount[i] {
+					t.Errorf("discount %d amount = %v, want %v", i, discount.Amount, tt.wantAmount[i])
+				}
+				if discount.OrderID != "order-1" || discount.Type != domain.OrderDiscountTypePromotion {
+					t.Errorf("discount %d = %+v, want promotion discount for order-1", i, discount)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package usecase
+
+import (
+	"butik/internal/domain"
+	"butik/internal/domain/dto"
+	"butik/internal/repository"
+	"errors"
+)
+
+type PromotionUsecase interface {
+	CreatePromotion(req domain.CreatePromotionRequest) (*domain.CreatePromotionResponse, error)
+	GetAllPromotions(offset, limit int) ([]*domain.PromotionResponse, int, error)
+	GetPromotionByID(id uint) (*domain.PromotionResponse, error)
+	UpdatePromotion(id uint, req domain.UpdatePromotionRequest) (*domain.UpdatePromotionResponse, error)
+	DeletePromotion(id uint) (*domain.DeletePromotionResponse, error)
+}
+
+type promotionUsecase struct {
+	promotionRepo repository.PromotionRepo
+	categoryRepo  repository.CategoryRepo
+	productRepo   repository.ProductRepo
+}
+
+func NewPromotionUsecase(promotionRepo repository.PromotionRepo, categoryRepo repository.CategoryRepo, productRepo repository.ProductRepo) PromotionUsecase {
+	return &promotionUsecase{
+		promotionRepo: promotionRepo,
+		categoryRepo:  categoryRepo,
+		productRepo:   productRepo,
+	}
+}
+
+func (u *promotionUsecase) CreatePromotion(req domain.CreatePromotionRequest) (*domain.CreatePromotionResponse, error) {
+	promotion, err := u.buildPromotion(domain.UpdatePromotionRequest(req))
+	if err != nil {
+		return nil, err
+	}
+
+	createdPromotion, err := u.promotionRepo.CreatePromotion(*promotion)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.CreatePromotionResponse{
+		Message:   "Promotion created successfully",
+		Promotion: *dto.ToPromotionResponse(createdPromotion),
+	}, nil
+}
+
+func (u *promotionUsecase) GetAllPromotions(offset, limit int) ([]*domain.PromotionResponse, int, error) {
+	promotions, total, err := u.promotionRepo.GetAllPromotions(offset, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	return dto.ToPromotionResponses(promotions), total, nil
+}
+
+func (u *promotionUsecase) GetPromotionByID(id uint) (*domain.PromotionResponse, error) {
+	promotion, err := u.promotionRepo.GetPromotionByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return dto.ToPromotionResponse(promotion), nil
+}
+
+func (u *promotionUsecase) UpdatePromotion(id uint, req domain.UpdatePromotionRequest) (*domain.UpdatePromotionResponse, error) {
+	promotion, err := u.buildPromotion(req)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedPromotion, err := u.promotionRepo.UpdatePromotion(id, *promotion)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.UpdatePromotionResponse{
+		Message:   "Promotion updated successfully",
+		Promotion: *dto.ToPromotionResponse(updatedPromotion),
+	}, nil
+}
+
+func (u *promotionUsecase) DeletePromotion(id uint) (*domain.DeletePromotionResponse, error) {
+	if err := u.promotionRepo.DeletePromotion(id); err != nil {
+		return nil, err
+	}
+	return &domain.DeletePromotionResponse{
+		Message: "Promotion deleted successfully",
+	}, nil
+}
+
+// buildPromotion validasi field wajib per tipe promo dan resolve scope
+func (u *promotionUsecase) buildPromotion(req domain.UpdatePromotionRequest) (*domain.Promotion, error) {
+	switch req.Type {
+	case domain.PromotionTypePercentage:
+		if req.DiscountValue <= 0 || req.DiscountValue > 100 {
+			return nil, errors.New("percentage promotion requires discount_value between 1 and 100")
+		}
+	case domain.PromotionTypeFixed:
+		if req.DiscountValue <= 0 {
+			return nil, errors.New("fixed promotion requires discount_value")
+		}
+	case domain.PromotionTypeBuyXGetY:
+		if req.BuyQuantity <= 0 || req.GetQuantity <= 0 {
+			return nil, errors.New("buy_x_get_y promotion requires buy_quantity and get_quantity")
+		}
+	case domain.PromotionTypeFreeProduct:
+		if req.RewardProductID == nil || req.RewardQuantity <= 0 {
+			return nil, errors.New("free_product promotion requires reward_product_id and reward_quantity")
+		}
+		if _, err := u.productRepo.GetProductByID(*req.RewardProductID); err != nil {
+			return nil, errors.New("reward product not found")
+		}
+	case domain.PromotionTypeBundle:
+		if len(req.ProductIDs) < 2 || len(req.CategoryIDs) > 0 {
+			return nil, errors.New("bundle promotion requires at least two product_ids and no category_ids")
+		}
+		if req.BundlePrice <= 0 {
+			return nil, errors.New("bundle promotion requires bundle_price")
+		}
+	}
+
+	if req.StartsAt != nil && req.EndsAt != nil && !req.EndsAt.After(*req.StartsAt) {
+		return nil, errors.New("promotion end date must be after start date")
+	}
+
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	categories := make([]domain.Category, 0, len(req.CategoryIDs))
+	for _, categoryID := range req.CategoryIDs {
+		category, err := u.categoryRepo.GetCategoryByID(categoryID)
+		if err != nil {
+			return nil, errors.New("category not found")
+		}
+		categories = append(categories, *category)
+	}
+
+	products := make([]domain.Product, 0, len(req.ProductIDs))
+	for _, productID := range req.ProductIDs {
+		product, err := u.productRepo.GetProductByID(productID)
+		if err != nil {
+			return nil, errors.New("product not found")
+		}
+		products = append(products, *product)
+	}
+
+	return &domain.Promotion{
+		Name:            req.Name,
+		Description:     req.Description,
+		Type:            req.Type,
+		Priority:        req.Priority,
+		Stackable:       req.Stackable,
+		IsActive:        isActive,
+		StartsAt:        req.StartsAt,
+		EndsAt:          req.EndsAt,
+		MinQuantity:     req.MinQuantity,
+		MinSubtotal:     req.MinSubtotal,
+		DiscountValue:   req.DiscountValue,
+		MaxDiscount:     req.MaxDiscount,
+		BuyQuantity:     req.BuyQuantity,
+		GetQuantity:     req.GetQuantity,
+		RewardProductID: req.RewardProductID,
+		RewardQuantity:  req.RewardQuantity,
+		BundlePrice:     req.BundlePrice,
+		Categories:      categories,
+		Products:        products,
+	}, nil
+}
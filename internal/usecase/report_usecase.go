@@ -0,0 +1,164 @@
+package usecase
+
+import (
+	"butik/internal/domain"
+	"butik/internal/repository"
+	"errors"
+	"time"
+)
+
+const (
+	defaultReportDays     = 30
+	defaultReportTopLimit = 10
+)
+
+type ReportUsecase interface {
+	GetSalesReport(query domain.ReportQuery) (*domain.SalesReportResponse, error)
+	GetTopProducts(query domain.ReportQuery) (*domain.TopProductsResponse, error)
+	GetTopCategories(query domain.ReportQuery) (*domain.TopCategoriesResponse, error)
+	GetStatusBreakdown(query domain.ReportQuery) (*domain.StatusBreakdownResponse, error)
+}
+
+type reportUsecase struct {
+	reportRepo repository.ReportRepo
+	location   *time.Location
+}
+
+func NewReportUsecase(reportRepo repository.ReportRepo, location *time.Location) ReportUsecase {
+	return &reportUsecase{
+		reportRepo: reportRepo,
+		location:   location,
+	}
+}
+
+func (u *reportUsecase) GetSalesReport(query domain.ReportQuery) (*domain.SalesReportResponse, error) {
+	from, to, err := u.reportRange(query)
+	if err != nil {
+		return nil, err
+	}
+
+	groupBy := query.GroupBy
+	if groupBy == "" {
+		groupBy = "day"
+	}
+
+	series, err := u.reportRepo.GetSalesByPeriod(from, to, groupBy, u.location.String())
+	if err != nil {
+		return nil, err
+	}
+
+	res := &domain.SalesReportResponse{
+		From:    from.Format(storeDateLayout),
+		To:      to.AddDate(0, 0, -1).Format(storeDateLayout),
+		GroupBy: groupBy,
+		Series:  series,
+	}
+	for _, row := range series {
+		res.OrderCount += row.OrderCount
+		res.Revenue += row.Revenue
+	}
+	if res.OrderCount > 0 {
+		res.AverageOrderValue = res.Revenue / float64(res.OrderCount)
+	}
+	return res, nil
+}
+
+func (u *reportUsecase) GetTopProducts(query domain.ReportQuery) (*domain.TopProductsResponse, error) {
+	from, to, err := u.reportRange(query)
+	if err != nil {
+		return nil, err
+	}
+
+	sortBy, limit := topReportOptions(query)
+	products, err := u.reportRepo.GetTopProducts(from, to, sortBy, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.TopProductsResponse{
+		From:     from.Format(storeDateLayout),
+		To:       to.AddDate(0, 0, -1).Format(storeDateLayout),
+		SortBy:   sortBy,
+		Products: products,
+	}, nil
+}
+
+func (u *reportUsecase) GetTopCategories(query domain.ReportQuery) (*domain.TopCategoriesResponse, error) {
+	from, to, err := u.reportRange(query)
+	if err != nil {
+		return nil, err
+	}
+
+	sortBy, limit := topReportOptions(query)
+	categories, err := u.reportRepo.GetTopCategories(from, to, sortBy, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.TopCategoriesResponse{
+		From:       from.Format(storeDateLayout),
+		To:         to.AddDate(0, 0, -1).Format(storeDateLayout),
+		SortBy:     sortBy,
+		Categories: categories,
+	}, nil
+}
+
+func (u *reportUsecase) GetStatusBreakdown(query domain.ReportQuery) (*domain.StatusBreakdownResponse, error) {
+	from, to, err := u.reportRange(query)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses, err := u.reportRepo.GetStatusBreakdown(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.StatusBreakdownResponse{
+		From:     from.Format(storeDateLayout),
+		To:       to.AddDate(0, 0, -1).Format(storeDateLayout),
+		Statuses: statuses,
+	}, nil
+}
+
+// reportRange ubah from/to (tanggal toko, inklusif) menjadi [from, to) pada tengah malam timezone toko.
+// Default 30 hari terakhir sampai hari ini.
+func (u *reportUsecase) reportRange(query domain.ReportQuery) (time.Time, time.Time, error) {
+	now := time.Now().In(u.location)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, u.location)
+
+	to := today
+	if query.To != "" {
+		parsed, err := parseStoreDate(query.To, u.location)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		to = parsed
+	}
+
+	from := to.AddDate(0, 0, -(defaultReportDays - 1))
+	if query.From != "" {
+		parsed, err := parseStoreDate(query.From, u.location)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		from = parsed
+	}
+
+	if to.Before(from) {
+		return time.Time{}, time.Time{}, errors.New("to date must not be before from date")
+	}
+	return from, to.AddDate(0, 0, 1), nil
+}
+
+func topReportOptions(query domain.ReportQuery) (string, int) {
+	sortBy := query.SortBy
+	if sortBy == "" {
+		sortBy = "revenue"
+	}
+	limit := query.Limit
+	if limit == 0 {
+		limit = defaultReportTopLimit
+	}
+	return sortBy, limit
+}
@@ -0,0 +1,17 @@
+package usecase
+
+import (
+	"errors"
+	"time"
+)
+
+const storeDateLayout = "2006-01-02"
+
+// parseStoreDate tanggal YYYY-MM-DD sebagai tengah malam di timezone toko
+func parseStoreDate(value string, location *time.Location) (time.Time, error) {
+	date, err := time.ParseInLocation(storeDateLayout, value, location)
+	if err != nil {
+		return time.Time{}, errors.New("invalid date, expected YYYY-MM-DD")
+	}
+	return date, nil
+}
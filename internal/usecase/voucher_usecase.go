@@ -0,0 +1,213 @@
+package usecase
+
+import (
+	"butik/internal/domain"
+	"butik/internal/domain/dto"
+	"butik/internal/repository"
+	"errors"
+	"strings"
+	"time"
+)
+
+type VoucherUsecase interface {
+	CreateVoucher(req domain.CreateVoucherRequest) (*domain.CreateVoucherResponse, error)
+	GetAllVouchers(offset, limit int) ([]*domain.VoucherResponse, int, error)
+	GetVoucherByID(id uint) (*domain.VoucherResponse, error)
+	UpdateVoucher(id uint, req domain.UpdateVoucherRequest) (*domain.UpdateVoucherResponse, error)
+	DeleteVoucher(id uint) (*domain.DeleteVoucherResponse, error)
+}
+
+type voucherUsecase struct {
+	voucherRepo  repository.VoucherRepo
+	categoryRepo repository.CategoryRepo
+	productRepo  repository.ProductRepo
+}
+
+func NewVoucherUsecase(voucherRepo repository.VoucherRepo, categoryRepo repository.CategoryRepo, productRepo repository.ProductRepo) VoucherUsecase {
+	return &voucherUsecase{
+		voucherRepo:  voucherRepo,
+		categoryRepo: categoryRepo,
+		productRepo:  productRepo,
+	}
+}
+
+func (u *voucherUsecase) CreateVoucher(req domain.CreateVoucherRequest) (*domain.CreateVoucherResponse, error) {
+	voucher, err := u.buildVoucher(domain.UpdateVoucherRequest(req))
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := u.voucherRepo.GetVoucherByCode(voucher.Code); err == nil {
+		return nil, errors.New("voucher code already exists")
+	}
+
+	createdVoucher, err := u.voucherRepo.CreateVoucher(*voucher)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.CreateVoucherResponse{
+		Message: "Voucher created successfully",
+		Voucher: *dto.ToVoucherResponse(createdVoucher),
+	}, nil
+}
+
+func (u *voucherUsecase) GetAllVouchers(offset, limit int) ([]*domain.VoucherResponse, int, error) {
+	vouchers, total, err := u.voucherRepo.GetAllVouchers(offset, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	return dto.ToVoucherResponses(vouchers), total, nil
+}
+
+func (u *voucherUsecase) GetVoucherByID(id uint) (*domain.VoucherResponse, error) {
+	voucher, err := u.voucherRepo.GetVoucherByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return dto.ToVoucherResponse(voucher), nil
+}
+
+func (u *voucherUsecase) UpdateVoucher(id uint, req domain.UpdateVoucherRequest) (*domain.UpdateVoucherResponse, error) {
+	voucher, err := u.buildVoucher(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if existing, err := u.voucherRepo.GetVoucherByCode(voucher.Code); err == nil && existing.ID != id {
+		return nil, errors.New("voucher code already exists")
+	}
+
+	updatedVoucher, err := u.voucherRepo.UpdateVoucher(id, *voucher)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.UpdateVoucherResponse{
+		Message: "Voucher updated successfully",
+		Voucher: *dto.ToVoucherResponse(updatedVoucher),
+	}, nil
+}
+
+func (u *voucherUsecase) DeleteVoucher(id uint) (*domain.DeleteVoucherResponse, error) {
+	if err := u.voucherRepo.DeleteVoucher(id); err != nil {
+		return nil, err
+	}
+	return &domain.DeleteVoucherResponse{
+		Message: "Voucher deleted successfully",
+	}, nil
+}
+
+// buildVoucher validasi request dan resolve scope category/product
+func (u *voucherUsecase) buildVoucher(req domain.UpdateVoucherRequest) (*domain.Voucher, error) {
+	if req.Type == domain.VoucherTypePercentage && req.Value > 100 {
+		return nil, errors.New("percentage voucher value must not exceed 100")
+	}
+
+	if req.StartsAt != nil && req.EndsAt != nil && !req.EndsAt.After(*req.StartsAt) {
+		return nil, errors.New("voucher end date must be after start date")
+	}
+
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	categories := make([]domain.Category, 0, len(req.CategoryIDs))
+	for _, categoryID := range req.CategoryIDs {
+		category, err := u.categoryRepo.GetCategoryByID(categoryID)
+		if err != nil {
+			return nil, errors.New("category not found")
+		}
+		categories = append(categories, *category)
+	}
+
+	products := make([]domain.Product, 0, len(req.ProductIDs))
+	for _, productID := range req.ProductIDs {
+		product, err := u.productRepo.GetProductByID(productID)
+		if err != nil {
+			return nil, errors.New("product not found")
+		}
+		products = append(products, *product)
+	}
+
+	return &domain.Voucher{
+		Code:                  strings.ToUpper(req.Code),
+		Description:           req.Description,
+		Type:                  req.Type,
+		Value:                 req.Value,
+		MinOrderValue:         req.MinOrderValue,
+		MaxDiscount:           req.MaxDiscount,
+		StartsAt:              req.StartsAt,
+		EndsAt:                req.EndsAt,
+		UsageLimit:            req.UsageLimit,
+		UsageLimitPerWhatsapp: req.UsageLimitPerWhatsapp,
+		IsActive:              isActive,
+		Categories:            categories,
+		Products:              products,
+	}, nil
+}
+
+// validateVoucher cek status, periode berlaku, minimal order dan kuota voucher
+func validateVoucher(voucher *domain.Voucher, subtotal float64, now time.Time) error {
+	if !voucher.IsActive {
+		return errors.New("voucher is not active")
+	}
+	if voucher.StartsAt != nil && now.Before(*voucher.StartsAt) {
+		return errors.New("voucher is not yet valid")
+	}
+	if voucher.EndsAt != nil && now.After(*voucher.EndsAt) {
+		return errors.New("voucher has expired")
+	}
+	if voucher.UsageLimit > 0 && voucher.UsedCount >= voucher.UsageLimit {
+		return errors.New("voucher usage limit reached")
+	}
+	if subtotal < voucher.MinOrderValue {
+		return errors.New("order does not meet voucher minimum value")
+	}
+	return nil
+}
+
+// calculateVoucherDiscount hitung potongan dari item yang masuk scope voucher
+func calculateVoucherDiscount(voucher *domain.Voucher, items []domain.OrderItem) float64 {
+	var eligible float64
+	for _, item := range items {
+		if productInScope(voucher.Categories, voucher.Products, &item.Product) {
+			eligible += item.PriceAtPurchase * float64(item.Quantity)
+		}
+	}
+
+	var discount float64
+	switch voucher.Type {
+	case domain.VoucherTypePercentage:
+		discount = eligible * voucher.Value / 100
+	case domain.VoucherTypeFixed:
+		discount = voucher.Value
+	}
+
+	if voucher.MaxDiscount > 0 && discount > voucher.MaxDiscount {
+		discount = voucher.MaxDiscount
+	}
+	if discount > eligible {
+		discount = eligible
+	}
+	return discount
+}
+
+// productInScope, scope kosong berarti berlaku untuk semua product
+func productInScope(categories []domain.Category, products []domain.Product, product *domain.Product) bool {
+	if len(categories) == 0 && len(products) == 0 {
+		return true
+	}
+	for _, prod := range products {
+		if prod.ID == product.ID {
+			return true
+		}
+	}
+	for _, cat := range categories {
+		if cat.ID == product.CategoryID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,323 @@
+package usecase
+
+import (
+	"butik/internal/domain"
+	"butik/internal/domain/dto"
+	"butik/internal/infrastructure"
+	"butik/internal/repository"
+	"encoding/json"
+	"errors"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	gonanoid "github.com/matoous/go-nanoid/v2"
+)
+
+const (
+	webhookBatchSize = 50
+	webhookLease     = 2 * time.Minute
+	webhookMaxDelay  = 6 * time.Hour
+)
+
+type WebhookUsecase interface {
+	Publish(event domain.WebhookEvent, data interface{}) error
+	DeliverDueWebhooks() (int, error)
+	CreateEndpoint(req domain.CreateWebhookEndpointRequest) (*domain.CreateWebhookEndpointResponse, error)
+	GetAllEndpoints(offset, limit int) ([]*domain.WebhookEndpointResponse, int, error)
+	GetEndpointByID(id uint) (*domain.WebhookEndpointResponse, error)
+	UpdateEndpoint(id uint, req domain.UpdateWebhookEndpointRequest) (*domain.UpdateWebhookEndpointResponse, error)
+	DeleteEndpoint(id uint) (*domain.DeleteWebhookEndpointResponse, error)
+	GetDeliveries(endpointID uint, query domain.WebhookDeliveryListQuery, offset, limit int) ([]*domain.WebhookDeliveryResponse, int, error)
+	GetDeliveryByID(id uint) (*domain.WebhookDeliveryResponse, error)
+	Redeliver(id uint) (*domain.RedeliverWebhookResponse, error)
+}
+
+type webhookUsecase struct {
+	webhookRepo repository.WebhookRepo
+	sender      infrastructure.WebhookSender
+	maxAttempts int
+}
+
+func NewWebhookUsecase(webhookRepo repository.WebhookRepo, sender infrastructure.WebhookSender, maxAttempts int) WebhookUsecase {
+	return &webhookUsecase{
+		webhookRepo: webhookRepo,
+		sender:      sender,
+		maxAttempts: maxAttempts,
+	}
+}
+
+// Publish simpan event untuk tiap endpoint aktif yang berlangganan, lalu kirim di background.
+// Pengiriman yang gagal diulang oleh job deliver_webhooks.
+func (u *webhookUsecase) Publish(event domain.WebhookEvent, data interface{}) error {
+	endpoints, err := u.webhookRepo.GetActiveEndpoints()
+	if err != nil {
+		return err
+	}
+
+	var subscribed []domain.WebhookEndpoint
+	for _, endpoint := range endpoints {
+		if slices.Contains(strings.Split(endpoint.Events, ","), string(event)) {
+			subscribed = append(subscribed, endpoint)
+		}
+	}
+	if len(subscribed) == 0 {
+		return nil
+	}
+
+	eventID, err := gonanoid.New()
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	payload, err := json.Marshal(domain.WebhookPayload{
+		ID:        "evt_" + eventID,
+		Event:     event,
+		CreatedAt: now.Format(time.RFC3339),
+		Data:      data,
+	})
+	if err != nil {
+		return errors.New("failed to encode webhook payload")
+	}
+
+	deliveries := make([]domain.WebhookDelivery, len(subscribed))
+	for i, endpoint := range subscribed {
+		deliveries[i] = domain.WebhookDelivery{
+			EndpointID:    endpoint.ID,
+			EventID:       "evt_" + eventID,
+			Event:         event,
+			Payload:       string(payload),
+			Status:        domain.WebhookDeliveryPending,
+			NextAttemptAt: now,
+		}
+	}
+
+	created, err := u.webhookRepo.CreateDeliveries(deliveries)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		for _, delivery := range created {
+			if err := u.deliver(delivery.ID); err != nil {
+				log.Println("Webhook delivery failed:", err)
+			}
+		}
+	}()
+	return nil
+}
+
+// DeliverDueWebhooks kirim ulang delivery yang jadwal retry-nya sudah lewat
+func (u *webhookUsecase) DeliverDueWebhooks() (int, error) {
+	ids, err := u.webhookRepo.GetDueDeliveryIDs(time.Now(), webhookBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	for _, id := range ids {
+		if err := u.deliver(id); err != nil {
+			log.Println("Webhook delivery failed:", err)
+			continue
+		}
+		delivered++
+	}
+	return delivered, nil
+}
+
+// deliver satu percobaan kirim, error dikembalikan hanya jika endpoint tidak menerima event
+func (u *webhookUsecase) deliver(id uint) error {
+	delivery, err := u.webhookRepo.ClaimDelivery(id, time.Now(), webhookLease)
+	if err != nil || delivery == nil {
+		return err
+	}
+
+	var result infrastructure.WebhookResult
+	sendErr := errors.New("webhook endpoint is inactive")
+	start := time.Now()
+	if delivery.Endpoint.IsActive {
+		result, sendErr = u.sender.Send(infrastructure.WebhookRequest{
+			URL:        delivery.Endpoint.URL,
+			Secret:     delivery.Endpoint.Secret,
+			Event:      string(delivery.Event),
+			DeliveryID: delivery.ID,
+			Body:       []byte(delivery.Payload),
+		})
+	}
+
+	now := time.Now()
+	delivery.Attempts++
+	delivery.ResponseStatus = result.StatusCode
+	attempt := domain.WebhookAttempt{
+		Attempt:        delivery.Attempts,
+		ResponseStatus: result.StatusCode,
+		ResponseBody:   result.ResponseBody,
+		DurationMs:     now.Sub(start).Milliseconds(),
+	}
+
+	if sendErr == nil {
+		delivery.Status = domain.WebhookDeliverySucceeded
+		delivery.DeliveredAt = &now
+		delivery.LastError = ""
+	} else {
+		attempt.Error = sendErr.Error()
+		delivery.LastError = sendErr.Error()
+		// Endpoint nonaktif tidak perlu dicoba lagi, admin bisa redeliver setelah diaktifkan
+		if !delivery.Endpoint.IsActive || delivery.Attempts >= u.maxAttempts {
+			delivery.Status = domain.WebhookDeliveryFailed
+		} else {
+			delivery.NextAttemptAt = now.Add(webhookBackoff(delivery.Attempts))
+		}
+	}
+
+	if err := u.webhookRepo.RecordAttempt(delivery, attempt); err != nil {
+		return err
+	}
+	return sendErr
+}
+
+// webhookBackoff 1, 2, 4, 8 ... menit, maksimal enam jam
+func webhookBackoff(attempts int) time.Duration {
+	delay := time.Minute << (attempts - 1)
+	if delay <= 0 || delay > webhookMaxDelay {
+		return webhookMaxDelay
+	}
+	return delay
+}
+
+func (u *webhookUsecase) CreateEndpoint(req domain.CreateWebhookEndpointRequest) (*domain.CreateWebhookEndpointResponse, error) {
+	secret, err := gonanoid.New(32)
+	if err != nil {
+		return nil, errors.New("failed to generate webhook secret")
+	}
+
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	endpoint, err := u.webhookRepo.CreateEndpoint(domain.WebhookEndpoint{
+		URL:         req.URL,
+		Description: req.Description,
+		Events:      joinWebhookEvents(req.Events),
+		Secret:      "whsec_" + secret,
+		IsActive:    isActive,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := dto.ToWebhookEndpointResponse(endpoint)
+	response.Secret = endpoint.Secret
+	return &domain.CreateWebhookEndpointResponse{
+		Message:  "Webhook endpoint created successfully",
+		Endpoint: *response,
+	}, nil
+}
+
+func (u *webhookUsecase) GetAllEndpoints(offset, limit int) ([]*domain.WebhookEndpointResponse, int, error) {
+	endpoints, total, err := u.webhookRepo.GetAllEndpoints(offset, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	return dto.ToWebhookEndpointResponses(endpoints), total, nil
+}
+
+// GetEndpointByID detail endpoint termasuk secret untuk verifikasi signature
+func (u *webhookUsecase) GetEndpointByID(id uint) (*domain.WebhookEndpointResponse, error) {
+	endpoint, err := u.webhookRepo.GetEndpointByID(id)
+	if err != nil {
+		return nil, err
+	}
+	response := dto.ToWebhookEndpointResponse(endpoint)
+	response.Secret = endpoint.Secret
+	return response, nil
+}
+
+func (u *webhookUsecase) UpdateEndpoint(id uint, req domain.UpdateWebhookEndpointRequest) (*domain.UpdateWebhookEndpointResponse, error) {
+	endpoint, err := u.webhookRepo.GetEndpointByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint.URL = req.URL
+	endpoint.Description = req.Description
+	endpoint.Events = joinWebhookEvents(req.Events)
+	if req.IsActive != nil {
+		endpoint.IsActive = *req.IsActive
+	}
+
+	updated, err := u.webhookRepo.UpdateEndpoint(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.UpdateWebhookEndpointResponse{
+		Message:  "Webhook endpoint updated successfully",
+		Endpoint: *dto.ToWebhookEndpointResponse(updated),
+	}, nil
+}
+
+// DeleteEndpoint hapus endpoint beserta log pengirimannya
+func (u *webhookUsecase) DeleteEndpoint(id uint) (*domain.DeleteWebhookEndpointResponse, error) {
+	if err := u.webhookRepo.DeleteEndpoint(id); err != nil {
+		return nil, err
+	}
+	return &domain.DeleteWebhookEndpointResponse{Message: "Webhook endpoint deleted successfully"}, nil
+}
+
+func (u *webhookUsecase) GetDeliveries(endpointID uint, query domain.WebhookDeliveryListQuery, offset, limit int) ([]*domain.WebhookDeliveryResponse, int, error) {
+	if _, err := u.webhookRepo.GetEndpointByID(endpointID); err != nil {
+		return nil, 0, err
+	}
+
+	deliveries, total, err := u.webhookRepo.GetDeliveries(endpointID, query, offset, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	return dto.ToWebhookDeliveryResponses(deliveries), total, nil
+}
+
+func (u *webhookUsecase) GetDeliveryByID(id uint) (*domain.WebhookDeliveryResponse, error) {
+	delivery, err := u.webhookRepo.GetDeliveryByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return dto.ToWebhookDeliveryResponse(delivery), nil
+}
+
+// Redeliver kirim ulang payload yang sama sekarang, juga untuk delivery yang sudah berhasil atau failed
+func (u *webhookUsecase) Redeliver(id uint) (*domain.RedeliverWebhookResponse, error) {
+	if _, err := u.webhookRepo.RequeueDelivery(id, time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := u.deliver(id); err != nil {
+		log.Println("Webhook delivery failed:", err)
+	}
+
+	delivery, err := u.webhookRepo.GetDeliveryByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	message := "Webhook delivered successfully"
+	if delivery.Status != domain.WebhookDeliverySucceeded {
+		message = "Webhook delivery failed"
+	}
+	return &domain.RedeliverWebhookResponse{
+		Message:  message,
+		Delivery: *dto.ToWebhookDeliveryResponse(delivery),
+	}, nil
+}
+
+// joinWebhookEvents simpan event tanpa duplikat, dipisah koma
+func joinWebhookEvents(events []string) string {
+	var unique []string
+	for _, event := range events {
+		if !slices.Contains(unique, event) {
+			unique = append(unique, event)
+		}
+	}
+	return strings.Join(unique, ",")
+}
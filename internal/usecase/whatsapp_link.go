@@ -0,0 +1,43 @@
+package usecase
+
+import (
+	"butik/internal/domain"
+	"butik/pkg/utils"
+	"net/url"
+	"strings"
+)
+
+// whatsappLinkEvents pesan yang cocok untuk status order saat ini
+func whatsappLinkEvents(order *domain.Order) map[domain.WhatsappLinkPurpose]domain.NotificationEvent {
+	switch order.Status {
+	case domain.OrderStatusPending:
+		return map[domain.WhatsappLinkPurpose]domain.NotificationEvent{
+			domain.WhatsappLinkPaymentReminder: domain.NotificationPaymentReminder,
+		}
+	case domain.OrderStatusSuccess:
+		events := map[domain.WhatsappLinkPurpose]domain.NotificationEvent{
+			domain.WhatsappLinkConfirmation: domain.NotificationPaymentVerified,
+		}
+		if order.AirwayBill != "" {
+			events[domain.WhatsappLinkShippingInfo] = domain.NotificationOrderShipped
+		}
+		return events
+	}
+	return nil
+}
+
+var whatsappLinkOrder = []domain.WhatsappLinkPurpose{
+	domain.WhatsappLinkPaymentReminder,
+	domain.WhatsappLinkConfirmation,
+	domain.WhatsappLinkShippingInfo,
+}
+
+// whatsappLink https://wa.me/<nomor tanpa +>?text=<pesan>
+func whatsappLink(whatsapp, message string) string {
+	link := "https://wa.me/" + strings.TrimPrefix(utils.NormalizeWhatsapp(whatsapp), "+")
+	if message == "" {
+		return link
+	}
+	// wa.me membaca + sebagai karakter biasa, spasi harus %20
+	return link + "?text=" + strings.ReplaceAll(url.QueryEscape(message), "+", "%20")
+}
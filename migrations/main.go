@@ -3,6 +3,9 @@ package main
 import (
 	"butik/internal/domain"
 	"butik/internal/infrastructure"
+	"butik/internal/repository"
+	"butik/pkg/utils"
+	"flag"
 	"fmt"
 
 	"golang.org/x/crypto/bcrypt"
@@ -10,14 +13,35 @@ import (
 )
 
 func main() {
+	normalizeWhatsapp := flag.Bool("normalize-whatsapp", false, "ubah nomor WhatsApp order lama dan pemakaian vouchernya ke format E.164 (tidak bisa dibatalkan)")
+	flag.Parse()
+
 	infrastructure.LoadEnv()
 	db := infrastructure.SetupDB()
 
+	if *normalizeWhatsapp {
+		updated, err := NormalizeOrderWhatsapp(db)
+		if err != nil {
+			fmt.Println("Failed to normalize WhatsApp numbers:", err)
+			return
+		}
+		fmt.Printf("Normalized WhatsApp numbers on %d orders\n", updated)
+		return
+	}
+
 	if err := SeedUser(db); err != nil {
 		fmt.Println("Failed add seed")
 	} else {
 		fmt.Println("Seeding user completed")
 	}
+
+	// Saldo awal ledger harus ada sebelum API menerima order, kalau tidak ledger hanya berisi penjualan
+	seeded, err := repository.NewStockMovementRepo(db).SeedOpeningBalances()
+	if err != nil {
+		fmt.Println("Failed to seed opening stock balances:", err)
+	} else {
+		fmt.Printf("Seeded opening stock balances for %d products\n", seeded)
+	}
 }
 
 func SeedUser(db *gorm.DB) error {
@@ -35,3 +59,45 @@ func SeedUser(db *gorm.DB) error {
 
 	return db.FirstOrCreate(&user, domain.User{Username: username}).Error
 }
+
+// NormalizeOrderWhatsapp migrasi sekali jalan untuk nomor WhatsApp order yang dibuat sebelum ada normalisasi.
+// Nomor di voucher_usages order yang sama ikut diubah supaya limit voucher per nomor menghitung pemakaian lama.
+// Nomor asli tidak disimpan, jadi migrasi ini tidak bisa dibatalkan.
+func NormalizeOrderWhatsapp(db *gorm.DB) (int, error) {
+	const batchSize = 500
+	updated := 0
+	lastID := ""
+	for {
+		var orders []domain.Order
+		err := db.Select("id, whatsapp").
+			Where("id > ? AND whatsapp ~ '[0-9]'", lastID).
+			Order("id ASC").
+			Limit(batchSize).
+			Find(&orders).Error
+		if err != nil {
+			return updated, err
+		}
+
+		for _, order := range orders {
+			normalized := utils.NormalizeWhatsapp(order.Whatsapp)
+			if normalized == order.Whatsapp {
+				continue
+			}
+			err := db.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Model(&domain.Order{}).Where("id = ?", order.ID).Update("whatsapp", normalized).Error; err != nil {
+					return err
+				}
+				return tx.Model(&domain.VoucherUsage{}).Where("order_id = ?", order.ID).Update("whatsapp", normalized).Error
+			})
+			if err != nil {
+				return updated, err
+			}
+			updated++
+		}
+
+		if len(orders) < batchSize {
+			return updated, nil
+		}
+		lastID = orders[len(orders)-1].ID
+	}
+}
@@ -0,0 +1,104 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+)
+
+const earthRadiusKm = 6371.0
+
+// HaversineKm jarak garis lurus dua koordinat dalam kilometer
+func HaversineKm(lat1, lng1, lat2, lng2 float64) float64 {
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+
+	dLat := toRad(lat2 - lat1)
+	dLng := toRad(lng2 - lng1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRad(lat1))*math.Cos(toRad(lat2))*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return earthRadiusKm * 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
+
+// Polygon ring pertama adalah batas luar, ring berikutnya adalah hole. Titik dalam format [lng, lat].
+type Polygon [][][2]float64
+
+type geoJSONObject struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates"`
+	Geometry    *geoJSONObject  `json:"geometry"`
+}
+
+// ParseGeoJSONPolygons terima geometry Polygon/MultiPolygon atau Feature yang membungkusnya
+func ParseGeoJSONPolygons(raw []byte) ([]Polygon, error) {
+	var obj geoJSONObject
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return nil, errors.New("invalid GeoJSON")
+	}
+	if obj.Type == "Feature" {
+		if obj.Geometry == nil {
+			return nil, errors.New("GeoJSON feature has no geometry")
+		}
+		obj = *obj.Geometry
+	}
+
+	var polygons []Polygon
+	switch obj.Type {
+	case "Polygon":
+		var polygon Polygon
+		if err := json.Unmarshal(obj.Coordinates, &polygon); err != nil {
+			return nil, errors.New("invalid GeoJSON polygon coordinates")
+		}
+		polygons = append(polygons, polygon)
+	case "MultiPolygon":
+		if err := json.Unmarshal(obj.Coordinates, &polygons); err != nil {
+			return nil, errors.New("invalid GeoJSON multipolygon coordinates")
+		}
+	default:
+		return nil, errors.New("GeoJSON geometry must be a Polygon or MultiPolygon")
+	}
+
+	for _, polygon := range polygons {
+		if len(polygon) == 0 {
+			return nil, errors.New("GeoJSON polygon has no rings")
+		}
+		for _, ring := range polygon {
+			if len(ring) < 4 || ring[0] != ring[len(ring)-1] {
+				return nil, errors.New("GeoJSON polygon rings must be closed with at least 4 positions")
+			}
+		}
+	}
+	return polygons, nil
+}
+
+// PointInPolygons true jika titik ada di dalam salah satu polygon (dan tidak di dalam hole-nya)
+func PointInPolygons(lat, lng float64, polygons []Polygon) bool {
+	for _, polygon := range polygons {
+		if !pointInRing(lat, lng, polygon[0]) {
+			continue
+		}
+		inHole := false
+		for _, hole := range polygon[1:] {
+			if pointInRing(lat, lng, hole) {
+				inHole = true
+				break
+			}
+		}
+		if !inHole {
+			return true
+		}
+	}
+	return false
+}
+
+// pointInRing ray casting
+func pointInRing(lat, lng float64, ring [][2]float64) bool {
+	inside := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		xi, yi := ring[i][0], ring[i][1]
+		xj, yj := ring[j][0], ring[j][1]
+		if (yi > lat) != (yj > lat) && lng < (xj-xi)*(lat-yi)/(yj-yi)+xi {
+			inside = !inside
+		}
+	}
+	return inside
+}
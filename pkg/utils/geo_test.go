@@ -0,0 +1,71 @@
+package utils
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHaversineKm(t *testing.T) {
+	tests := []struct {
+		name                   string
+		lat1, lng1, lat2, lng2 float64
+		want                   float64
+	}{
+		{name: "titik sama", lat1: -6.2, lng1: 106.8, lat2: -6.2, lng2: 106.8, want: 0},
+		{name: "satu derajat lintang", lat1: 0, lng1: 0, lat2: 1, lng2: 0, want: 111.19},
+		{name: "satu derajat bujur di ekuator", lat1: 0, lng1: 0, lat2: 0, lng2: 1, want: 111.19},
+		{name: "Monas ke Gedung Sate", lat1: -6.1754, lng1: 106.8272, lat2: -6.9025, lng2: 107.6188, want: 119.2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := HaversineKm(tt.lat1, tt.lng1, tt.lat2, tt.lng2)
+			if math.Abs(got-tt.want) > 0.5 {
+				t.Errorf("HaversineKm = %.2f, want %.2f", got, tt.want)
+			}
+			// Jarak harus simetris
+			if back := HaversineKm(tt.lat2, tt.lng2, tt.lat1, tt.lng1); math.Abs(back-got) > 1e-9 {
+				t.Errorf("HaversineKm not symmetric: %.6f vs %.6f", got, back)
+			}
+		})
+	}
+}
+
+func TestPointInPolygons(t *testing.T) {
+	// Kotak 0..10 dengan hole 4..6, titik dalam format [lng, lat]
+	square := Polygon{
+		{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}},
+		{{4, 4}, {6, 4}, {6, 6}, {4, 6}, {4, 4}},
+	}
+	// Segitiga terpisah di 20..30
+	triangle := Polygon{
+		{{20, 20}, {30, 20}, {25, 30}, {20, 20}},
+	}
+	polygons := []Polygon{square, triangle}
+
+	tests := []struct {
+		name     string
+		lat, lng float64
+		want     bool
+	}{
+		{name: "di dalam kotak", lat: 2, lng: 2, want: true},
+		{name: "di dalam hole", lat: 5, lng: 5, want: false},
+		{name: "di antara hole dan batas luar", lat: 5, lng: 8, want: true},
+		{name: "di luar kotak", lat: 5, lng: 15, want: false},
+		{name: "di dalam polygon kedua", lat: 22, lng: 25, want: true},
+		{name: "di luar semua polygon", lat: 29, lng: 21, want: false},
+		{name: "lat dan lng tertukar", lat: 25, lng: 22, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PointInPolygons(tt.lat, tt.lng, polygons); got != tt.want {
+				t.Errorf("PointInPolygons(%v, %v) = %v, want %v", tt.lat, tt.lng, got, tt.want)
+			}
+		})
+	}
+
+	if PointInPolygons(2, 2, nil) {
+		t.Error("PointInPolygons with no polygons = true, want false")
+	}
+}
@@ -0,0 +1,95 @@
+package utils
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// TableWriter tulis baris tabel satu per satu, dipakai untuk export CSV/XLSX
+type TableWriter interface {
+	WriteRow(values []interface{}) error
+	// Close flush sisa data ke writer tujuan
+	Close() error
+}
+
+type csvTableWriter struct {
+	writer *csv.Writer
+}
+
+func NewCSVTableWriter(w io.Writer) TableWriter {
+	return &csvTableWriter{writer: csv.NewWriter(w)}
+}
+
+func (t *csvTableWriter) WriteRow(values []interface{}) error {
+	record := make([]string, len(values))
+	for i, value := range values {
+		switch v := value.(type) {
+		case string:
+			record[i] = sanitizeCSVCell(v)
+		case float64:
+			// Tanpa notasi eksponen supaya nominal besar tetap terbaca spreadsheet
+			record[i] = strconv.FormatFloat(v, 'f', -1, 64)
+		case int:
+			record[i] = strconv.Itoa(v)
+		default:
+			record[i] = fmt.Sprint(value)
+		}
+	}
+	return t.writer.Write(record)
+}
+
+func (t *csvTableWriter) Close() error {
+	t.writer.Flush()
+	return t.writer.Error()
+}
+
+// sanitizeCSVCell cegah formula injection saat CSV dibuka di spreadsheet
+func sanitizeCSVCell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+type xlsxTableWriter struct {
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	out    io.Writer
+	row    int
+}
+
+// NewXLSXTableWriter baris disimpan lewat StreamWriter excelize (spill ke file sementara jika besar),
+// file dikirim ke w saat Close
+func NewXLSXTableWriter(w io.Writer, sheet string) (TableWriter, error) {
+	file := excelize.NewFile()
+	if err := file.SetSheetName("Sheet1", sheet); err != nil {
+		return nil, err
+	}
+	stream, err := file.NewStreamWriter(sheet)
+	if err != nil {
+		return nil, err
+	}
+	return &xlsxTableWriter{file: file, stream: stream, out: w, row: 1}, nil
+}
+
+func (t *xlsxTableWriter) WriteRow(values []interface{}) error {
+	cell, err := excelize.CoordinatesToCellName(1, t.row)
+	if err != nil {
+		return err
+	}
+	t.row++
+	return t.stream.SetRow(cell, values)
+}
+
+func (t *xlsxTableWriter) Close() error {
+	defer t.file.Close()
+	if err := t.stream.Flush(); err != nil {
+		return err
+	}
+	return t.file.Write(t.out)
+}
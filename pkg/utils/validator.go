@@ -11,6 +11,23 @@ import (
 
 type CustomValidator struct {
 	Validator *validator.Validate
+	// Language bahasa default pesan validasi untuk customer (id/en)
+	Language string
+}
+
+// whatsappMessages pesan validasi nomor WhatsApp per bahasa, dibaca customer saat checkout
+var whatsappMessages = map[string]string{
+	"id": "harus nomor WhatsApp yang valid, contoh 081234567890 atau +6281234567890",
+	"en": "must be a valid WhatsApp number, e.g. 081234567890 or +6281234567890",
+}
+
+// NewCustomValidator validator dengan aturan tambahan aplikasi
+func NewCustomValidator(language string) *CustomValidator {
+	v := validator.New()
+	v.RegisterValidation("whatsapp", func(fl validator.FieldLevel) bool {
+		return IsValidWhatsapp(fl.Field().String())
+	})
+	return &CustomValidator{Validator: v, Language: language}
 }
 
 func (cv *CustomValidator) Validate(i interface{}) error {
@@ -28,6 +45,7 @@ func (cv *CustomValidator) Validate(i interface{}) error {
 			}
 
 			typ := val.Type()
+			language := cv.requestLanguage(val)
 			for _, ve := range validationErrors {
 				field, _ := typ.FieldByName(ve.Field())
 				jsonTag := field.Tag.Get("json")
@@ -35,7 +53,7 @@ func (cv *CustomValidator) Validate(i interface{}) error {
 				if jsonName == "" {
 					jsonName = strings.ToLower(ve.Field())
 				}
-				errors[jsonName] = formatValidationError(ve)
+				errors[jsonName] = formatValidationError(ve, language)
 			}
 			return echo.NewHTTPError(http.StatusBadRequest, map[string]interface{}{
 				"status":  400,
@@ -48,6 +66,19 @@ func (cv *CustomValidator) Validate(i interface{}) error {
 	return nil
 }
 
+// requestLanguage bahasa dari field Language di request, default bahasa toko
+func (cv *CustomValidator) requestLanguage(val reflect.Value) string {
+	if val.Kind() == reflect.Struct {
+		field := val.FieldByName("Language")
+		if field.IsValid() && field.Kind() == reflect.String {
+			if _, ok := whatsappMessages[field.String()]; ok {
+				return field.String()
+			}
+		}
+	}
+	return cv.Language
+}
+
 // SanitizeStruct trims whitespace
 func SanitizeStruct(i interface{}) {
 	val := reflect.ValueOf(i)
@@ -69,10 +100,14 @@ func SanitizeStruct(i interface{}) {
 }
 
 // formatValidationError
-func formatValidationError(ve validator.FieldError) string {
+func formatValidationError(ve validator.FieldError, language string) string {
 	switch ve.Tag() {
 	case "required":
 		return "field is required"
+	case "required_unless":
+		return "field is required unless " + ve.Param()
+	case "required_with":
+		return "field is required when " + ve.Param() + " is set"
 	case "min":
 		return "minimum length is " + ve.Param()
 	case "max":
@@ -91,6 +126,13 @@ func formatValidationError(ve validator.FieldError) string {
 		return "must be numeric"
 	case "alphanum":
 		return "must be alphanumeric"
+	case "datetime":
+		return "must be a date in format " + ve.Param()
+	case "whatsapp":
+		if message, ok := whatsappMessages[language]; ok {
+			return message
+		}
+		return whatsappMessages["en"]
 	case "oneof":
 		return "must be one of: " + ve.Param()
 	default:
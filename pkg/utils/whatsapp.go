@@ -0,0 +1,123 @@
+package utils
+
+import (
+	"errors"
+	"strings"
+)
+
+const indonesiaCountryCode = "62"
+
+var errInvalidWhatsapp = errors.New("invalid whatsapp number")
+
+// ParseWhatsapp ubah nomor WhatsApp ke format E.164 (+6281234567890).
+// Nomor tanpa kode negara dianggap nomor Indonesia (0812..., 812...),
+// nomor luar negeri wajib diawali + atau 00.
+func ParseWhatsapp(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", errInvalidWhatsapp
+	}
+
+	// Pemisah yang biasa dipakai saat menulis nomor
+	cleaned := strings.NewReplacer(" ", "", "-", "", ".", "", "(", "", ")", "").Replace(value)
+
+	international := false
+	switch {
+	case strings.HasPrefix(cleaned, "+"):
+		cleaned = cleaned[1:]
+		international = true
+	case strings.HasPrefix(cleaned, "00"):
+		cleaned = cleaned[2:]
+		international = true
+	}
+
+	if cleaned == "" || !isDigits(cleaned) {
+		return "", errInvalidWhatsapp
+	}
+
+	var digits string
+	switch {
+	case international:
+		digits = cleaned
+	case strings.HasPrefix(cleaned, "0"):
+		digits = indonesiaCountryCode + cleaned[1:]
+	case strings.HasPrefix(cleaned, indonesiaCountryCode):
+		digits = cleaned
+	case strings.HasPrefix(cleaned, "8"):
+		digits = indonesiaCountryCode + cleaned
+	default:
+		return "", errInvalidWhatsapp
+	}
+
+	if strings.HasPrefix(digits, indonesiaCountryCode) {
+		// Nomor seluler Indonesia: 8xx dengan 9-12 digit setelah kode negara
+		national := digits[len(indonesiaCountryCode):]
+		if !strings.HasPrefix(national, "8") || len(national) < 9 || len(national) > 12 {
+			return "", errInvalidWhatsapp
+		}
+	} else if strings.HasPrefix(digits, "0") || len(digits) < 8 || len(digits) > 15 {
+		// E.164: kode negara tidak diawali 0, maksimal 15 digit
+		return "", errInvalidWhatsapp
+	}
+
+	return "+" + digits, nil
+}
+
+// IsValidWhatsapp nomor bisa diubah ke E.164
+func IsValidWhatsapp(value string) bool {
+	_, err := ParseWhatsapp(value)
+	return err == nil
+}
+
+// NormalizeWhatsapp seperti ParseWhatsapp, tapi untuk data lama yang tidak valid
+// tetap dikembalikan dalam bentuk +digit supaya penulisan yang sama tetap cocok
+func NormalizeWhatsapp(value string) string {
+	if normalized, err := ParseWhatsapp(value); err == nil {
+		return normalized
+	}
+
+	var b strings.Builder
+	for _, r := range value {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	digits := b.String()
+	if digits == "" {
+		return ""
+	}
+
+	if strings.HasPrefix(digits, "0") {
+		digits = indonesiaCountryCode + strings.TrimLeft(digits, "0")
+	}
+	return "+" + digits
+}
+
+// WhatsappSearchDigits ubah potongan nomor yang dicari ke digit seperti yang tersimpan (tanpa +),
+// awalan 0 jadi 62 dan 00 dibuang, kosong jika tidak ada digit
+func WhatsappSearchDigits(value string) string {
+	var b strings.Builder
+	for _, r := range value {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	digits := b.String()
+
+	switch {
+	case strings.HasPrefix(digits, "00"):
+		return digits[2:]
+	case strings.HasPrefix(digits, "0"):
+		return indonesiaCountryCode + digits[1:]
+	}
+	return digits
+}
+
+func isDigits(value string) bool {
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,70 @@
+package utils
+
+import "testing"
+
+func TestParseWhatsapp(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{name: "awalan 0", input: "081234567890", want: "+6281234567890"},
+		{name: "tanpa awalan", input: "81234567890", want: "+6281234567890"},
+		{name: "awalan 62", input: "6281234567890", want: "+6281234567890"},
+		{name: "dengan pemisah", input: " +62 812-3456.7890 ", want: "+6281234567890"},
+		{name: "dengan kurung", input: "(0812) 3456 7890", want: "+6281234567890"},
+		{name: "luar negeri plus", input: "+65 9123 4567", want: "+6591234567"},
+		{name: "luar negeri 00", input: "0065 9123 4567", want: "+6591234567"},
+		{name: "kosong", input: "   ", wantErr: true},
+		{name: "hanya plus", input: "+", wantErr: true},
+		{name: "huruf", input: "0812abc4567", wantErr: true},
+		{name: "bukan seluler Indonesia", input: "0215551234", wantErr: true},
+		{name: "seluler terlalu pendek", input: "0812345", wantErr: true},
+		{name: "seluler terlalu panjang", input: "08123456789012", wantErr: true},
+		{name: "luar negeri tanpa plus", input: "6591234567", wantErr: true},
+		{name: "kode negara diawali 0", input: "+0123456789", wantErr: true},
+		{name: "luar negeri terlalu panjang", input: "+1234567890123456", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseWhatsapp(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseWhatsapp(%q) = %q, want error", tt.input, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseWhatsapp(%q) error: %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseWhatsapp(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWhatsappSearchDigits(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "awalan 0 jadi 62", input: "0812", want: "62812"},
+		{name: "awalan 00 dibuang", input: "0065 9123", want: "659123"},
+		{name: "plus dibuang", input: "+62 812-34", want: "6281234"},
+		{name: "potongan tengah", input: "3456", want: "3456"},
+		{name: "tanpa digit", input: "abc", want: ""},
+		{name: "kosong", input: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := WhatsappSearchDigits(tt.input); got != tt.want {
+				t.Errorf("WhatsappSearchDigits(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}